@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	quotaUC usecase.IQuotaUsecase
+	token   string
+}
+
+func NewAdminController(quotaUC usecase.IQuotaUsecase, token string) *AdminController {
+	return &AdminController{quotaUC: quotaUC, token: token}
+}
+
+// RequireToken checks the X-Admin-Token header. Without a configured admin
+// token the admin API is disabled.
+func (ac *AdminController) RequireToken(c *gin.Context) {
+	if ac.token == "" {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "admin API is disabled"})
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Token")), []byte(ac.token)) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+		return
+	}
+	c.Next()
+}
+
+func (ac *AdminController) Quota(c *gin.Context) {
+	budgets, err := ac.quotaUC.Budgets(c.Request.Context())
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"budgets": budgets})
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+	"github.com/gin-gonic/gin"
+)
+
+// dateOptions reads the calendar=, tz= and lang= query parameters.
+func dateOptions(c *gin.Context) (ethiotime.Options, error) {
+	return ethiotime.ParseOptions(c.Query("calendar"), c.Query("tz"), c.Query("lang"))
+}
+
+// localizePrevFixtures returns a copy of fixtures with DateLocal filled in,
+// leaving cached slices untouched.
+func localizePrevFixtures(fixtures *[]domain.PrevFixtures, opts ethiotime.Options) *[]domain.PrevFixtures {
+	if fixtures == nil {
+		return nil
+	}
+
+	out := make([]domain.PrevFixtures, len(*fixtures))
+	for i, f := range *fixtures {
+		f.DateLocal = opts.FormatTimestamp(f.Date)
+		out[i] = f
+	}
+	return &out
+}
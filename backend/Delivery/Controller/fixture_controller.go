@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
@@ -11,10 +14,13 @@ import (
 
 type FixturesController struct {
 	FixureUC usecase.IFixturesUsecase
+	leagues  domain.ILeagueRegistry
+	seasons  usecase.ISeasonCalendar
+	live     usecase.ILiveHub
 }
 
-func NewFixturesController(uc usecase.IFixturesUsecase) *FixturesController {
-	return &FixturesController{FixureUC: uc}
+func NewFixturesController(uc usecase.IFixturesUsecase, leagues domain.ILeagueRegistry, seasons usecase.ISeasonCalendar, live usecase.ILiveHub) *FixturesController {
+	return &FixturesController{FixureUC: uc, leagues: leagues, seasons: seasons, live: live}
 }
 
 func (hc *FixturesController) PreviousMatchHistory(c *gin.Context) {
@@ -31,62 +37,79 @@ func (hc *FixturesController) PreviousMatchHistory(c *gin.Context) {
 		return
 	}
 
-	if league != "EPL" && league != "ETH" {
-		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid league queries"})
+	opts, err := dateOptions(c)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		c.Abort()
 		return
 	}
 
-	getSeason := func(defaultSeason int) int {
-		if seasonQuery != "" {
-			if season, err := strconv.Atoi(seasonQuery); err == nil {
-				return season
-			}
-		}
-		return defaultSeason
+	leagueCfg, err := hc.leagues.ByCode(league)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid league queries"})
+		c.Abort()
+		return
 	}
+	league = leagueCfg.Code
+
 
-	var leagueID int
-	if league == "EPL" {
-		leagueID = 39
-	} else {
-		leagueID = 363
+	season, err := strconv.Atoi(seasonQuery)
+	if err != nil {
+		season, err = hc.seasons.CurrentSeason(c.Request.Context(), league)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
 	}
 
-	season := getSeason(2023)
 	q := domain.RoundQuery{League: league, Season: season, Round: round, From: from, To: to}
 
 	rq, err := hc.FixureUC.ResolveRoundWindow(c.Request.Context(), q)
-	if err == nil {
-		q = rq
+	if errors.Is(err, domain.ErrRoundNotFound) {
+		c.IndentedJSON(http.StatusNotFound, gin.H{"error": "round not found"})
+		c.Abort()
+		return
+	}
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Abort()
+		return
 	}
+	q = rq
 
 	cached, err := hc.FixureUC.GetCachedByRound(c.Request.Context(), q)
 	if err == nil {
-		c.IndentedJSON(http.StatusOK, gin.H{"result": cached, "source": "cache"})
+		if opts.IsSet() {
+			cached = localizePrevFixtures(cached, opts)
+		}
+		c.IndentedJSON(http.StatusOK, gin.H{"result": cached, "source": "cache", "meta": domain.CacheMetas(c.Request.Context())})
 		return
 	}
 
-	result, err := hc.FixureUC.FetchAndStore(c.Request.Context(), league, leagueID, q)
+	result, err := hc.FixureUC.FetchAndStore(c.Request.Context(), league, q)
 	if err != nil {
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		c.Abort()
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"result": result, "source": "api"})
+	if opts.IsSet() {
+		result = localizePrevFixtures(result, opts)
+	}
+	c.IndentedJSON(http.StatusOK, gin.H{"result": result, "source": "api", "meta": domain.CacheMetas(c.Request.Context())})
 }
 
 func (fc *FixturesController) LiveFixtures(c *gin.Context) {
 
-	league := c.Query("league")
-	if league != "EPL" && league != "ETH" {
+	league, err := fc.leagues.ByCode(c.Query("league"))
+	if err != nil {
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unsupported league queries"})
 		c.Abort()
 		return
 	}
 
-	result, err := fc.FixureUC.GetLiveMatches(league)
+	result, err := fc.FixureUC.GetLiveMatches(c.Request.Context(), league.Code)
 	// if result == nil && err != nil {
 	// 	c.IndentedJSON(http.StatusOK, gin.H{"result": result})
 	// 	return
@@ -100,35 +123,69 @@ func (fc *FixturesController) LiveFixtures(c *gin.Context) {
 	c.IndentedJSON(http.StatusOK, gin.H{"result": result})
 }
 
-func (fc *FixturesController) Fixture(c *gin.Context){
+// MatchDetail returns a fixture with its goal, card and substitution
+// timeline, starting lineups and team statistics.
+func (fc *FixturesController) MatchDetail(c *gin.Context) {
 
-		league := c.Query("league")
-		team := c.Query("team")
-		season := c.Query("season")
-		from := c.Query("from")
-		to := c.Query("to")
+	fixtureID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid fixture ID format"})
+		return
+	}
 
-		// Validate required parameters
-		if league == "" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "league parameter is required"})
-			return
-		}
+	opts, err := dateOptions(c)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-		fixtures, err := fc.FixureUC.GetFixtures(
-			c.Request.Context(), // Pass context
-			league,
-			team,
-			season,
-			from,
-			to,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	detail, err := fc.FixureUC.MatchDetail(c.Request.Context(), fixtureID)
+	if err != nil {
+		if errors.Is(err, domain.ErrFixtureNotFound) {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "fixture not found"})
 			return
 		}
-		if fixtures == nil {
-			fixtures = []domain.Fixture{}
-		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	detail.DateLocal = opts.FormatTimestamp(detail.Date)
+
+	c.IndentedJSON(http.StatusOK, gin.H{"match": detail, "meta": domain.CacheMetas(c.Request.Context())})
+}
+
+// LiveStream sends live score events for a league as Server-Sent Events.
+func (fc *FixturesController) LiveStream(c *gin.Context) {
 
-		c.JSON(http.StatusOK, gin.H{"fixtures": fixtures})
+	leagueCfg, err := fc.leagues.ByCode(c.Query("league"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unsupported league queries"})
+		c.Abort()
+		return
+	}
+
+	events, unsubscribe := fc.live.Subscribe(leagueCfg.Code)
+	defer unsubscribe()
+
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(e.Type, e)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
 }
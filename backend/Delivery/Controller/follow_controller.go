@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// userIDHeader identifies the caller until the API has real authentication.
+const userIDHeader = "X-User-ID"
+
+type FollowController struct {
+	followUC usecase.IFollowUsecase
+}
+
+func NewFollowController(followUC usecase.IFollowUsecase) *FollowController {
+	return &FollowController{followUC: followUC}
+}
+
+// RequireUser rejects requests without a user ID header and stores it on the context.
+// IDs in the Telegram namespace belong to bot chats and are refused, as a
+// follow made under one would send alerts to that chat.
+func (fc *FollowController) RequireUser(c *gin.Context) {
+	userID := c.GetHeader(userIDHeader)
+	if userID == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing " + userIDHeader + " header"})
+		return
+	}
+	if strings.HasPrefix(userID, domain.TelegramUserPrefix) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "reserved " + userIDHeader})
+		return
+	}
+	c.Set("userID", userID)
+	c.Next()
+}
+
+func (fc *FollowController) Follow(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid team ID format"})
+		return
+	}
+
+	req := struct {
+		Notify *bool `json:"notify"`
+	}{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+	notify := true
+	if req.Notify != nil {
+		notify = *req.Notify
+	}
+
+	follow, err := fc.followUC.Follow(c.Request.Context(), c.GetString("userID"), teamID, notify)
+	if err != nil {
+		if errors.Is(err, domain.ErrTeamNotFound) {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusCreated, gin.H{"follow": follow})
+}
+
+func (fc *FollowController) Unfollow(c *gin.Context) {
+	teamID, err := strconv.Atoi(c.Param("teamId"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid team ID format"})
+		return
+	}
+
+	if err := fc.followUC.Unfollow(c.Request.Context(), c.GetString("userID"), teamID); err != nil {
+		if errors.Is(err, domain.ErrFollowNotFound) {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (fc *FollowController) List(c *gin.Context) {
+	follows, err := fc.followUC.List(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"follows": follows})
+}
+
+func (fc *FollowController) Feed(c *gin.Context) {
+	opts, err := dateOptions(c)
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed, err := fc.followUC.Feed(c.Request.Context(), c.GetString("userID"))
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if opts.IsSet() {
+		for i := range feed.Upcoming {
+			feed.Upcoming[i].DateLocal = opts.FormatTimestamp(feed.Upcoming[i].DateUTC)
+		}
+		for i := range feed.Results {
+			feed.Results[i].DateLocal = opts.FormatTimestamp(feed.Results[i].DateUTC)
+		}
+		for i := range feed.CalledOff {
+			feed.CalledOff[i].DateLocal = opts.FormatTimestamp(feed.CalledOff[i].DateUTC)
+		}
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"feed": feed})
+}
@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/http"
 	"strconv"
-	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	"github.com/abrshodin/ethio-fb-backend/Usecase"
@@ -14,30 +13,30 @@ import (
 )
 
 type IntentController struct {
-	parseIntent *usecase.ParseIntentUseCase
-	standingUC	*StandingsController
-	newsUC      *NewsController
-	teamUC 		*TeamController
-	answerC     *AnswerController
-	fixtureUC   usecase.IFixturesUsecase
+	parseIntent usecase.IConversationUsecase
+	intents     *usecase.IntentRegistry
+	answers     usecase.AnswerUsecase
+	teams       usecase.TeamUsecases
+	leagues     domain.ILeagueRegistry
+	seasons     usecase.ISeasonCalendar
 }
 
 func NewIntentController(
-	parseIntent *usecase.ParseIntentUseCase, 
-	st *StandingsController, 
-	ns *NewsController, 
-	tc *TeamController, 
-	answerHander *AnswerController,
-	fixtureUC   usecase.IFixturesUsecase,
-	) *IntentController {
+	parseIntent usecase.IConversationUsecase,
+	intents *usecase.IntentRegistry,
+	answers usecase.AnswerUsecase,
+	teams usecase.TeamUsecases,
+	leagues domain.ILeagueRegistry,
+	seasons usecase.ISeasonCalendar,
+) *IntentController {
 
 	return &IntentController{
 		parseIntent: parseIntent,
-		standingUC: st,
-		newsUC: ns,
-		teamUC: tc,
-		answerC: answerHander,
-		fixtureUC: fixtureUC,
+		intents:     intents,
+		answers:     answers,
+		teams:       teams,
+		leagues:     leagues,
+		seasons:     seasons,
 	}
 }
 
@@ -45,7 +44,8 @@ func (h *IntentController) ParseIntent(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	var req struct {
-		Text string `json:"text"`
+		Text      string `json:"text"`
+		SessionID string `json:"session_id"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -53,11 +53,13 @@ func (h *IntentController) ParseIntent(c *gin.Context) {
 		return
 	}
 
-	intent, err := h.parseIntent.Execute(req.Text)
+	conv, intent, err := h.parseIntent.Parse(ctx, req.SessionID, req.Text)
 	if err != nil {
 		switch {
 		case errors.Is(err, usecase.ErrInvalidInput):
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrIntentNotFound):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		default:
 			log.Print(err)
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
@@ -67,112 +69,33 @@ func (h *IntentController) ParseIntent(c *gin.Context) {
 
 	fmt.Println("intent : ", intent)
 
-	var data any
-	season := 2022
-	leagueID := 0
-
-	switch intent.League {
-	case "ETH":
-		leagueID = 363
-	case "EPL":
-		leagueID = 39
-	}
-
-	switch intent.Topic {
-	case "fixture":
-			query := domain.RoundQuery{
-				League: intent.League,
-				Season: 2022,
-				Round: "1",
-			}
-			
-			fmt.Println("query :", query)
-			response := make([]any, 0)
-			answer, err := h.fixtureUC.GetCachedByRound(ctx, query)
-			if err != nil {
-				break
-			}
-			response = append(response, answer)
-			data = response
-
-		fmt.Println("data :", data)
-
-	case "table":
-		data, err = h.standingUC.standingsUsecase.GetStandings(ctx, leagueID, season)
-		fmt.Println("data :", data)
-		if err != nil {
+	answerContext, err := h.intents.Dispatch(ctx, intent)
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrInvalidInput), errors.Is(err, usecase.ErrUnsupportedTopic), errors.Is(err, domain.ErrLeagueNotFound):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
 			fmt.Println("err :", err)
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching standings"})
-			return
-		}
-
-	case "news":
-		var answer []any
-		if ans, err := h.newsUC.newsUC.GenerateStandingNews(); err == nil {
-			answer = append(answer, ans)
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching data for " + intent.Topic})
 		}
-		if ans, err := h.newsUC.newsUC.GenerateFutureNews(); err == nil {
-			answer = append(answer, ans)
-		}
-		if ans, err := h.newsUC.newsUC.GenerateLiveScores(); err == nil {
-			answer = append(answer, ans)
-		}
-		if ans, err := h.newsUC.newsUC.GenerateNews(); err == nil {
-			answer = append(answer, ans)
-		}
-		data = answer
-
-	case "compare":
-		if len(intent.Teams) < 2 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "two teams are required for comparison"})
-			return
-		}
-
-		teamA := intent.Teams[0]
-		teamB := intent.Teams[1]
-
-		team1Data, err := h.teamUC.teamUsecase.Statistics(ctx, leagueID, season, teamA)
-		if err != nil {
-			fmt.Println("error", err)
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching data for team A"})
-			return
-		}
-
-		team2Data, err := h.teamUC.teamUsecase.Statistics(ctx, leagueID, season, teamB)
-		if err != nil {
-			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching data for team B"})
-			return
-		}
-
-		data = domain.ComparisonData{
-			TeamA: team1Data,
-			TeamB: team2Data,
-		}
-	case "fact":
-		data = intent.Teams
-			
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported topic"})
 		return
 	}
 
-	cData := map[string]interface{}{"data": data}
-	answerContext := domain.AnswerContext{
-		Topic:       intent.Topic,
-		Language:    intent.Language,
-		Source:      "api",
-		Freshness:   time.Now(),
-		ContextData: cData,
-	}
-
 	// Call answer usecase
-	answer, err := h.answerC.answerUsecase.Compose(ctx, answerContext)
+	answer, err := h.answers.Compose(ctx, *answerContext)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compose answer"})
 		return
 	}
 
-	c.JSON(http.StatusOK, answer)
+	if err := h.parseIntent.Remember(ctx, conv, req.Text, intent, answer); err != nil {
+		fmt.Println("could not save conversation:", err)
+	}
+
+	c.JSON(http.StatusOK, struct {
+		*domain.Answer
+		SessionID string `json:"session_id"`
+	}{answer, conv.ID})
 }
 
 func (h *IntentController) HandleCompare(c *gin.Context){
@@ -189,25 +112,30 @@ func (h *IntentController) HandleCompare(c *gin.Context){
 	team_a, _ := strconv.Atoi(teamA)
 	team_b, _ := strconv.Atoi(teamB) 
 
-	leagueID := 0
-	if league == "ETH" {
-		leagueID = 363
-	} else if league == "EPL" {
-		leagueID = 39
-	} else {
+	leagueCfg, err := h.leagues.ByCode(league)
+	if err != nil {
 		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unsupported league"})
 		return 
 	}
+	leagueID := leagueCfg.APISportsID
+
+	season, err := strconv.Atoi(c.Query("season"))
+	if err != nil {
+		season, err = h.seasons.CurrentSeason(c.Request.Context(), leagueCfg.Code)
+		if err != nil {
+			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while resolving current season"})
+			return
+		}
+	}
 
-	season := 2022
-	team1Data, err := h.teamUC.teamUsecase.StatisticsByID(c, leagueID, season, team_a)
+	team1Data, err := h.teams.StatisticsByID(c.Request.Context(), leagueID, season, team_a)
 		if err != nil {
 			fmt.Println("error", err)
 			c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching data for team A"})
 			return
 		}
 
-	team2Data, err := h.teamUC.teamUsecase.StatisticsByID(c, leagueID, season, team_b)
+	team2Data, err := h.teams.StatisticsByID(c.Request.Context(), leagueID, season, team_b)
 	if err != nil {
 		fmt.Print("err : ", err)
 		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching data for team B"})
@@ -219,6 +147,6 @@ func (h *IntentController) HandleCompare(c *gin.Context){
 		TeamB: team2Data,
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"comparison_data": data})
+	c.IndentedJSON(http.StatusOK, gin.H{"comparison_data": data, "season": season, "meta": domain.CacheMetas(c.Request.Context())})
 }
 
@@ -1,22 +1,147 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type NewsController struct {
-	newsUC *usecase.NewsUseCase
+	newsUC  *usecase.NewsUseCase
+	leagues domain.ILeagueRegistry
+	baseURL string
 }
 
-func NewNewsController(newsUC *usecase.NewsUseCase) *NewsController {
-	return &NewsController{newsUC: newsUC}
+// NewNewsController serves the news API and feeds. baseURL is the public
+// scheme and host the API is reached at, used for absolute links in feeds.
+func NewNewsController(newsUC *usecase.NewsUseCase, leagues domain.ILeagueRegistry, baseURL string) *NewsController {
+	return &NewsController{newsUC: newsUC, leagues: leagues, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// Feed returns stored news items, newest first. It filters by team, league
+// and since (RFC3339 or YYYY-MM-DD) and pages with limit and offset.
+func (c *NewsController) Feed(ctx *gin.Context) {
+	items, q, ok := c.feedItems(ctx)
+	if !ok {
+		return
+	}
+
+	res := gin.H{"status": "success", "news": items}
+	if len(items) == q.Limit {
+		res["next_offset"] = q.Offset + q.Limit
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// feedItems reads the feed filters from the query and loads the matching
+// items in the requested language. It writes the error response itself
+// and reports false on failure.
+func (c *NewsController) feedItems(ctx *gin.Context) ([]domain.NewsItem, domain.NewsQuery, bool) {
+	q := domain.NewsQuery{}
+	if team := ctx.Query("team"); team != "" {
+		q.Team = c.newsUC.TeamTag(team)
+	}
+
+	if league := ctx.Query("league"); league != "" {
+		leagueCfg, err := c.leagues.Resolve(league)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "unsupported league"})
+			return nil, q, false
+		}
+		// stories are only written about one league
+		if leagueCfg.Code != c.newsUC.League().Code {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "news is only published for " + c.newsUC.League().Code})
+			return nil, q, false
+		}
+		q.League = leagueCfg.Code
+	}
+
+	if since := ctx.Query("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", since)
+		}
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "since must be an RFC3339 time or a YYYY-MM-DD date"})
+			return nil, q, false
+		}
+		q.Since = t
+	}
+
+	var err error
+	q.Limit, err = strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+	if err != nil || q.Limit <= 0 || q.Limit > 100 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "limit must be between 1 and 100"})
+		return nil, q, false
+	}
+	q.Offset, err = strconv.Atoi(ctx.DefaultQuery("offset", "0"))
+	if err != nil || q.Offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"status": "error", "message": "offset must not be negative"})
+		return nil, q, false
+	}
+
+	items, err := c.newsUC.Feed(ctx.Request.Context(), q)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error()})
+		return nil, q, false
+	}
+
+	if lang := newsLanguage(ctx); lang != "" {
+		for i := range items {
+			items[i] = items[i].In(lang)
+		}
+	}
+	return items, q, true
+}
+
+// Article returns one stored news item, for deep links.
+func (c *NewsController) Article(ctx *gin.Context) {
+	item, err := c.newsUC.Article(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		if errors.Is(err, domain.ErrNewsNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"status": "error", "message": "news item not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+
+	if lang := newsLanguage(ctx); lang != "" {
+		localized := item.In(lang)
+		item = &localized
+	}
+	ctx.JSON(http.StatusOK, gin.H{"status": "success", "news": item})
+}
+
+// newsLanguage reads the lang query value ("en", "am", "english" or
+// "amharic"); it is empty when the client wants every translation.
+func newsLanguage(ctx *gin.Context) string {
+	switch strings.ToLower(strings.TrimSpace(ctx.Query("lang"))) {
+	case "am", "amharic":
+		return "am"
+	case "en", "english":
+		return "en"
+	}
+	return ""
 }
 
 func (c *NewsController) GetNews(ctx *gin.Context) {
-	news, err := c.newsUC.GenerateNews()
+	opts, err := dateOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	news, err := c.newsUC.GenerateNews(opts)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -48,7 +173,16 @@ func (c *NewsController) GetStandingNews(ctx *gin.Context) {
 }
 
 func (c *NewsController) GetFutureNews(ctx *gin.Context) {
-	news, err := c.newsUC.GenerateFutureNews()
+	opts, err := dateOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	news, err := c.newsUC.GenerateFutureNews(opts)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -64,7 +198,16 @@ func (c *NewsController) GetFutureNews(ctx *gin.Context) {
 }
 
 func (c *NewsController) GetLiveScores(ctx *gin.Context) {
-	news, err := c.newsUC.GenerateLiveScores()
+	opts, err := dateOptions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	news, err := c.newsUC.GenerateLiveScores(opts)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
@@ -0,0 +1,331 @@
+package controller
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/gin-gonic/gin"
+)
+
+// newsGUIDPrefix turns news item IDs into globally unique, permanent
+// identifiers (RFC 4151 tag URIs) that do not change with the host name.
+const (
+	newsGUIDPrefix   = "tag:ethio-fb-backend,2024:news/"
+	newsFeedIDPrefix = "tag:ethio-fb-backend,2024:feed"
+)
+
+// syndication is what the RSS, Atom and JSON feeds are rendered from.
+type syndication struct {
+	ID       string
+	Title    string
+	HomeURL  string
+	SelfURL  string
+	Language string
+	Updated  time.Time
+	Items    []domain.NewsItem
+	base     string
+}
+
+// syndicate loads the filtered news items for a feed; it takes the same
+// team, league, since, limit and lang parameters as /news.
+func (c *NewsController) syndicate(ctx *gin.Context) (*syndication, bool) {
+	items, q, ok := c.feedItems(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	base := c.baseURL
+	feed := &syndication{
+		ID:       newsFeedID(q),
+		Title:    "Ethiopian football news",
+		HomeURL:  base + "/news",
+		SelfURL:  base + ctx.Request.URL.RequestURI(),
+		Language: "en",
+		Items:    items,
+		base:     base,
+	}
+	if lang := newsLanguage(ctx); lang != "" {
+		feed.Language = lang
+	}
+	if q.League != "" {
+		if league, err := c.leagues.ByCode(q.League); err == nil {
+			feed.Title += " – " + league.Name(feed.Language)
+		}
+	}
+	if q.Team != "" {
+		feed.Title += " – " + q.Team
+	}
+
+	for _, item := range items {
+		if t := newsUpdated(item); t.After(feed.Updated) {
+			feed.Updated = t
+		}
+	}
+	if feed.Updated.IsZero() {
+		feed.Updated = time.Now().UTC()
+	}
+
+	ctx.Header("Cache-Control", "public, max-age=300")
+	return feed, true
+}
+
+func (f *syndication) link(item domain.NewsItem) string {
+	if strings.HasPrefix(item.URL, "http://") || strings.HasPrefix(item.URL, "https://") {
+		return item.URL
+	}
+	return f.base + item.URL
+}
+
+func newsPublished(item domain.NewsItem) time.Time {
+	t, _ := time.Parse(time.RFC3339, item.PublishedAt)
+	return t
+}
+
+func newsUpdated(item domain.NewsItem) time.Time {
+	if t, err := time.Parse(time.RFC3339, item.UpdatedAt); err == nil {
+		return t
+	}
+	return newsPublished(item)
+}
+
+// newsCategories tags an item with its league and teams.
+func newsCategories(item domain.NewsItem) []string {
+	var tags []string
+	if item.League != "" {
+		tags = append(tags, item.League)
+	}
+	return append(tags, item.Teams...)
+}
+
+// newsFeedID identifies a feed by its filters alone, with the team by its
+// canonical name, so paging, the format, spelling and the order of the
+// query parameters do not change it.
+func newsFeedID(q domain.NewsQuery) string {
+	filters := url.Values{}
+	if q.League != "" {
+		filters.Set("league", q.League)
+	}
+	if q.Team != "" {
+		filters.Set("team", strings.ToLower(q.Team))
+	}
+	if len(filters) == 0 {
+		return newsFeedIDPrefix
+	}
+	return newsFeedIDPrefix + "?" + filters.Encode()
+}
+
+type rssDoc struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	Language      string      `xml:"language"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	Self          rssAtomLink `xml:"atom:link"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// FeedRSS serves the news feed as RSS 2.0.
+func (c *NewsController) FeedRSS(ctx *gin.Context) {
+	feed, ok := c.syndicate(ctx)
+	if !ok {
+		return
+	}
+
+	doc := rssDoc{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.HomeURL,
+			Description:   "Results, fixtures and news from Ethiopian and English football",
+			Language:      feed.Language,
+			LastBuildDate: feed.Updated.Format(time.RFC1123Z),
+			Self:          rssAtomLink{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+			Items:         []rssItem{},
+		},
+	}
+	for _, item := range feed.Items {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       item.Title,
+			Link:        feed.link(item),
+			Description: item.Snippet,
+			GUID:        rssGUID{IsPermaLink: "false", Value: newsGUIDPrefix + item.ID},
+			PubDate:     newsPublished(item).Format(time.RFC1123Z),
+			Categories:  newsCategories(item),
+		})
+	}
+
+	writeXML(ctx, "application/rss+xml; charset=utf-8", doc)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Lang    string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Link       atomLink       `xml:"link"`
+	Summary    string         `xml:"summary"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// FeedAtom serves the news feed as Atom 1.0.
+func (c *NewsController) FeedAtom(ctx *gin.Context) {
+	feed, ok := c.syndicate(ctx)
+	if !ok {
+		return
+	}
+
+	doc := atomFeed{
+		Lang:    feed.Language,
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.HomeURL, Rel: "alternate", Type: "application/json"},
+		},
+		Author: atomAuthor{Name: newsAuthor},
+	}
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			ID:        newsGUIDPrefix + item.ID,
+			Title:     item.Title,
+			Updated:   newsUpdated(item).UTC().Format(time.RFC3339),
+			Published: newsPublished(item).UTC().Format(time.RFC3339),
+			Link:      atomLink{Href: feed.link(item), Rel: "alternate"},
+			Summary:   item.Snippet,
+		}
+		for _, tag := range newsCategories(item) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	writeXML(ctx, "application/atom+xml; charset=utf-8", doc)
+}
+
+// newsAuthor credits the generated stories in feeds that require an author.
+const newsAuthor = "Ethio FB"
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Language    string         `json:"language"`
+	Authors     []jsonAuthor   `json:"authors"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string   `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	ContentText   string   `json:"content_text"`
+	Summary       string   `json:"summary"`
+	DatePublished string   `json:"date_published"`
+	DateModified  string   `json:"date_modified,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+}
+
+// FeedJSON serves the news feed as JSON Feed 1.1.
+func (c *NewsController) FeedJSON(ctx *gin.Context) {
+	feed, ok := c.syndicate(ctx)
+	if !ok {
+		return
+	}
+
+	doc := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		HomePageURL: feed.HomeURL,
+		FeedURL:     feed.SelfURL,
+		Language:    feed.Language,
+		Authors:     []jsonAuthor{{Name: newsAuthor}},
+		Items:       []jsonFeedItem{},
+	}
+	for _, item := range feed.Items {
+		doc.Items = append(doc.Items, jsonFeedItem{
+			ID:            newsGUIDPrefix + item.ID,
+			URL:           feed.link(item),
+			Title:         item.Title,
+			ContentText:   item.Snippet,
+			Summary:       item.Snippet,
+			DatePublished: item.PublishedAt,
+			DateModified:  item.UpdatedAt,
+			Tags:          newsCategories(item),
+		})
+	}
+
+	ctx.Header("Content-Type", "application/feed+json; charset=utf-8")
+	ctx.JSON(http.StatusOK, doc)
+}
+
+func writeXML(ctx *gin.Context, contentType string, doc any) {
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"status": "error", "message": err.Error()})
+		return
+	}
+	ctx.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
@@ -0,0 +1,119 @@
+package controller_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	controller "github.com/abrshodin/ethio-fb-backend/Delivery/Controller"
+	routers "github.com/abrshodin/ethio-fb-backend/Delivery/Router"
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func newsRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	leagues, err := infrastructure.LoadLeagueRegistry("../../config/leagues.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := infrastructure.LoadTeamAliases("../../config/team_aliases.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := infrastructure.LoadReportTemplates("../../config/report_templates.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer, err := usecase.NewReportWriter(templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	league := leagues.Default()
+	news := usecase.NewNewsUseCase(fake.NewEventRepository(), storedNews{}, fake.NewStandingsRepo(fake.NewAPIService()),
+		usecase.NewTeamResolver(nil, aliases), writer, &league)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routers.RegisterNewsRoutes(router, controller.NewNewsController(news, leagues, "https://fb.example/"))
+	return router
+}
+
+func atomFeed(t *testing.T, router *gin.Engine, target string) (id string, links []string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Host = "attacker.example"
+	req.Header.Set("X-Forwarded-Proto", "gopher")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s: %d %s", target, rec.Code, rec.Body)
+	}
+
+	var doc struct {
+		ID    string `xml:"id"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Entries []struct {
+			Link struct {
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range doc.Links {
+		links = append(links, l.Href)
+	}
+	for _, e := range doc.Entries {
+		links = append(links, e.Link.Href)
+	}
+	return doc.ID, links
+}
+
+func TestFeedIDDependsOnlyOnFilters(t *testing.T) {
+	router := newsRouter(t)
+
+	id, links := atomFeed(t, router, "/news/feed.atom?team=St.+George&league=ETH&limit=5")
+	sameID, _ := atomFeed(t, router, "/news/feed.atom?limit=10&offset=10&league=eth&team=Saint+George")
+	if id != sameID {
+		t.Errorf("feed ids differ for the same filters: %q and %q", id, sameID)
+	}
+	if otherID, _ := atomFeed(t, router, "/news/feed.atom?league=ETH"); otherID == id {
+		t.Errorf("feed id %q does not tell the team filter apart", id)
+	}
+
+	for _, link := range links {
+		if !strings.HasPrefix(link, "https://fb.example/") {
+			t.Errorf("link %q is not under the configured base URL", link)
+		}
+	}
+}
+
+// storedNews holds one story about Saint George.
+type storedNews struct{}
+
+func (storedNews) SaveNews(ctx context.Context, item domain.NewsItem) error { return nil }
+
+func (storedNews) GetNews(ctx context.Context, id string) (*domain.NewsItem, error) {
+	return nil, domain.ErrNewsNotFound
+}
+
+func (storedNews) ListNews(ctx context.Context, q domain.NewsQuery) ([]domain.NewsItem, error) {
+	return []domain.NewsItem{{
+		ID:          "1",
+		Title:       "Saint George win",
+		URL:         "/news/1",
+		League:      "ETH",
+		Teams:       []string{"Saint George"},
+		PublishedAt: "2024-03-02T15:00:00Z",
+	}}, nil
+}
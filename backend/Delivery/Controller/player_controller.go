@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PlayerController struct {
+	players usecase.IPlayerUsecase
+	teams   domain.ITeamDirectory
+	leagues domain.ILeagueRegistry
+	seasons usecase.ISeasonCalendar
+}
+
+func NewPlayerController(players usecase.IPlayerUsecase, teams domain.ITeamDirectory, leagues domain.ILeagueRegistry, seasons usecase.ISeasonCalendar) *PlayerController {
+	return &PlayerController{players: players, teams: teams, leagues: leagues, seasons: seasons}
+}
+
+// Squad returns the squad of a team. The season defaults to the current
+// season of the team's league.
+func (pc *PlayerController) Squad(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	teamID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid team ID format"})
+		return
+	}
+
+	league := c.Query("league")
+	if league == "" {
+		league = pc.teamLeague(teamID)
+	}
+	season, status, err := pc.season(ctx, c.Query("season"), league)
+	if err != nil {
+		c.IndentedJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	squad, err := pc.players.Squad(ctx, teamID, season)
+	if err != nil {
+		if errors.Is(err, domain.ErrTeamNotFound) {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "team not found"})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching squad"})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"squad": squad, "season": season, "meta": domain.CacheMetas(ctx)})
+}
+
+// Player returns a player's profile with statistics for the season, which
+// defaults to the current season of league (the default league when not
+// given).
+func (pc *PlayerController) Player(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	playerID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "invalid player ID format"})
+		return
+	}
+
+	season, status, err := pc.season(ctx, c.Query("season"), c.DefaultQuery("league", pc.leagues.Default().Code))
+	if err != nil {
+		c.IndentedJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	player, err := pc.players.Player(ctx, playerID, season)
+	if err != nil {
+		if errors.Is(err, domain.ErrPlayerNotFound) {
+			c.IndentedJSON(http.StatusNotFound, gin.H{"error": "player not found"})
+			return
+		}
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching player"})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"player": player, "season": season, "meta": domain.CacheMetas(ctx)})
+}
+
+// TopScorers returns the scoring chart of a league with goals, assists and cards.
+func (pc *PlayerController) TopScorers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	league := c.Query("league")
+	if league == "" {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "league parameter is required"})
+		return
+	}
+	season, status, err := pc.season(ctx, c.Query("season"), league)
+	if err != nil {
+		c.IndentedJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	scorers, err := pc.players.TopScorers(ctx, league, season)
+	if err != nil {
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching top scorers"})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"top_scorers": scorers, "season": season, "meta": domain.CacheMetas(ctx)})
+}
+
+// season reads the season query parameter, defaulting to the current
+// season of league. On failure it also returns the HTTP status to answer
+// with.
+func (pc *PlayerController) season(ctx context.Context, query, league string) (int, int, error) {
+	leagueCfg, err := pc.leagues.Resolve(league)
+	if err != nil {
+		return 0, http.StatusBadRequest, fmt.Errorf("unsupported league")
+	}
+	return seasonParam(ctx, pc.seasons, leagueCfg.Code, query)
+}
+
+// teamLeague finds the league of a known team, defaulting to the default
+// league.
+func (pc *PlayerController) teamLeague(teamID int) string {
+	for _, t := range pc.teams.Teams("") {
+		if t.ID == teamID {
+			return t.League
+		}
+	}
+	return pc.leagues.Default().Code
+}
@@ -0,0 +1,94 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	controller "github.com/abrshodin/ethio-fb-backend/Delivery/Controller"
+	routers "github.com/abrshodin/ethio-fb-backend/Delivery/Router"
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+func playerRouter(t *testing.T, seasons usecase.ISeasonCalendar) *gin.Engine {
+	t.Helper()
+	leagues, err := infrastructure.LoadLeagueRegistry("../../config/leagues.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := infrastructure.LoadTeamAliases("../../config/team_aliases.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	routers.RegisterPlayerRoutes(router, controller.NewPlayerController(scorers{}, usecase.NewTeamResolver(nil, aliases), leagues, seasons))
+	return router
+}
+
+func get(router *gin.Engine, target string) int {
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	return rec.Code
+}
+
+func TestTopScorersSeasons(t *testing.T) {
+	router := playerRouter(t, runningSeason{season: 2026, configured: []int{2021, 2022, 2023}})
+
+	for target, want := range map[string]int{
+		"/api/topscorers?league=ETH":             http.StatusOK,
+		"/api/topscorers?league=ETH&season=2026": http.StatusOK,
+		"/api/topscorers?league=ETH&season=2022": http.StatusOK,
+		"/api/topscorers?league=ETH&season=2025": http.StatusBadRequest,
+		"/api/topscorers?league=ETH&season=next": http.StatusBadRequest,
+	} {
+		if got := get(router, target); got != want {
+			t.Errorf("GET %s = %d, want %d", target, got, want)
+		}
+	}
+}
+
+func TestTopScorersWithoutACalendar(t *testing.T) {
+	router := playerRouter(t, runningSeason{err: errors.New("redis is down")})
+
+	if got := get(router, "/api/topscorers?league=ETH"); got != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500 when the current season cannot be resolved", got)
+	}
+}
+
+// runningSeason is a calendar whose current season is season, or which
+// fails with err.
+type runningSeason struct {
+	usecase.ISeasonCalendar
+	season     int
+	configured []int
+	err        error
+}
+
+func (s runningSeason) CurrentSeason(ctx context.Context, league string) (int, error) {
+	return s.season, s.err
+}
+
+func (s runningSeason) HasSeason(ctx context.Context, league string, season int) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	for _, c := range s.configured {
+		if c == season {
+			return true, nil
+		}
+	}
+	return season == s.season, nil
+}
+
+type scorers struct{ usecase.IPlayerUsecase }
+
+func (scorers) TopScorers(ctx context.Context, league string, season int) ([]domain.TopScorer, error) {
+	return []domain.TopScorer{}, nil
+}
@@ -1,67 +1,144 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type StandingsController struct {
 	standingsUsecase usecase.IStandingsUsecase
+	leagues          domain.ILeagueRegistry
+	seasons          usecase.ISeasonCalendar
 }
 
-func NewStandingsController(standingsUsecase usecase.IStandingsUsecase) *StandingsController {
+func NewStandingsController(standingsUsecase usecase.IStandingsUsecase, leagues domain.ILeagueRegistry, seasons usecase.ISeasonCalendar) *StandingsController {
 	return &StandingsController{
 		standingsUsecase: standingsUsecase,
+		leagues:          leagues,
+		seasons:          seasons,
 	}
 }
 
 func (c *StandingsController) GetStandings(ctx *gin.Context) {
-	
-	league := ctx.Query("league")
-	seasonQuery := ctx.Query("season")
+	leagueCfg, season, ok := c.leagueSeason(ctx)
+	if !ok {
+		return
+	}
+	leagueID := leagueCfg.APISportsID
 
-	
-	if league == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "league parameter is required"})
+	opts := usecase.StandingsOptions{AsOf: ctx.Query("asOf"), Venue: ctx.Query("venue")}
+	if !usecase.ValidAsOf(opts.AsOf) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "asOf must be a round number or a YYYY-MM-DD date"})
 		return
 	}
-	if seasonQuery == "" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "season parameter is required"})
+	if !usecase.ValidVenue(opts.Venue) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "venue must be home or away"})
 		return
 	}
 
-	leagueID := 0
-	if league == "ETH" {
-		leagueID = 363
-	} else if league == "EPL" {
-		leagueID = 39
+	// A past point in the season or a home/away split is only available
+	// from a table computed over stored results
+	var (
+		standings *domain.StandingsResponse
+		err       error
+	)
+	if opts.AsOf != "" || opts.Venue != "" {
+		standings, err = c.standingsUsecase.ComputeStandings(ctx.Request.Context(), leagueID, season, opts)
 	} else {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unsupported league"})
-		return
+		standings, err = c.standingsUsecase.GetStandings(ctx.Request.Context(), leagueID, season)
 	}
-
-	season, err := strconv.Atoi(seasonQuery)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "season must be a valid year between 2021 and 2023"})
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get standings: " + err.Error()})
 		return
 	}
 
-	if  season < 2021 || season > 2023 {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "season must be a valid year between 2021 and 2023"})
+	ctx.JSON(http.StatusOK, struct {
+		*domain.StandingsResponse
+		Meta []domain.CacheMeta `json:"meta"`
+	}{standings, domain.CacheMetas(ctx.Request.Context())})
+}
+
+// History returns a team's rank and points over a season, for position charts.
+func (c *StandingsController) History(ctx *gin.Context) {
+	leagueCfg, season, ok := c.leagueSeason(ctx)
+	if !ok {
+		return
+	}
+	team := ctx.Query("team")
+	if team == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "team parameter is required"})
 		return
 	}
 
-	// Get standings from usecase
-	standings, err := c.standingsUsecase.GetStandings(ctx, leagueID, season)
+	history, err := c.standingsUsecase.History(ctx.Request.Context(), leagueCfg.APISportsID, season, team)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get standings: " + err.Error()})
+		if errors.Is(err, domain.ErrTeamNotFound) {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "team not found in this league's table"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get standings history: " + err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, standings)
+	ctx.JSON(http.StatusOK, gin.H{"history": history, "meta": domain.CacheMetas(ctx.Request.Context())})
 }
 
+// leagueSeason reads the league and season query parameters. It writes the
+// error response itself and reports false when they are unusable.
+func (c *StandingsController) leagueSeason(ctx *gin.Context) (*domain.LeagueConfig, int, bool) {
+	league := ctx.Query("league")
+	seasonQuery := ctx.Query("season")
+
+	if league == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "league parameter is required"})
+		return nil, 0, false
+	}
+	leagueCfg, err := c.leagues.ByCode(league)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unsupported league"})
+		return nil, 0, false
+	}
+
+	// Default to the season the calendar reports as current for the league
+	season, status, err := seasonParam(ctx.Request.Context(), c.seasons, leagueCfg.Code, seasonQuery)
+	if err != nil {
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return nil, 0, false
+	}
+	return leagueCfg, season, true
+}
+
+// seasonParam reads an explicit season, which must be one the calendar
+// knows for league, or defaults to the league's current season. On failure
+// it also returns the HTTP status to answer with.
+func seasonParam(ctx context.Context, seasons usecase.ISeasonCalendar, league, query string) (int, int, error) {
+	if query == "" {
+		season, err := seasons.CurrentSeason(ctx, league)
+		if err != nil {
+			return 0, http.StatusInternalServerError, fmt.Errorf("could not resolve the current season")
+		}
+		return season, http.StatusOK, nil
+	}
+
+	season, err := strconv.Atoi(query)
+	if err != nil {
+		return 0, http.StatusBadRequest, errSeasonUnavailable
+	}
+	ok, err := seasons.HasSeason(ctx, league, season)
+	if err != nil {
+		return 0, http.StatusInternalServerError, fmt.Errorf("could not resolve the seasons of this league")
+	}
+	if !ok {
+		return 0, http.StatusBadRequest, errSeasonUnavailable
+	}
+	return season, http.StatusOK, nil
+}
 
+var errSeasonUnavailable = errors.New("season must be one of the seasons available for this league")
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -12,10 +14,12 @@ import (
 
 type TeamController struct {
 	teamUsecase usecase.TeamUsecases
+	leagues     domain.ILeagueRegistry
+	resolver    usecase.ITeamResolver
 }
 
-func NewTeamController(teamUsecase usecase.TeamUsecases) *TeamController {
-	return &TeamController{teamUsecase: teamUsecase}
+func NewTeamController(teamUsecase usecase.TeamUsecases, leagues domain.ILeagueRegistry, resolver usecase.ITeamResolver) *TeamController {
+	return &TeamController{teamUsecase: teamUsecase, leagues: leagues, resolver: resolver}
 }
 
 func (tc *TeamController) GetTeam(c *gin.Context) {
@@ -37,7 +41,87 @@ func (tc *TeamController) GetTeam(c *gin.Context) {
 		return
 	}
 
-	c.IndentedJSON(http.StatusOK, gin.H{"team": team})
+	c.IndentedJSON(http.StatusOK, gin.H{"team": team, "meta": domain.CacheMetas(ctx)})
+}
+
+// ResolveTeam returns the teams that best match a name in any language or spelling.
+func (tc *TeamController) ResolveTeam(c *gin.Context) {
+	query := c.Query("q")
+	if query == "" {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "q parameter is required"})
+		return
+	}
+
+	league := ""
+	if c.Query("league") != "" {
+		leagueCfg, err := tc.leagues.Resolve(c.Query("league"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unsupported league"})
+			return
+		}
+		league = leagueCfg.Code
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "5"))
+	if err != nil || limit <= 0 {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"matches": tc.resolver.Resolve(query, league, limit)})
+}
+
+// HeadToHead returns the previous meetings of two teams. teamA and teamB
+// take an api-sports id or a name in any language or spelling.
+func (tc *TeamController) HeadToHead(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	league := ""
+	if c.Query("league") != "" {
+		leagueCfg, err := tc.leagues.Resolve(c.Query("league"))
+		if err != nil {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "unsupported league"})
+			return
+		}
+		league = leagueCfg.Code
+	}
+
+	teamA, errA := tc.teamID(ctx, c.Query("teamA"), league)
+	teamB, errB := tc.teamID(ctx, c.Query("teamB"), league)
+	if errA != nil || errB != nil {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "two known teams are needed"})
+		return
+	}
+
+	last, err := strconv.Atoi(c.DefaultQuery("last", "10"))
+	if err != nil || last <= 0 || last > 50 {
+		c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "last must be between 1 and 50"})
+		return
+	}
+
+	h2h, err := tc.teamUsecase.HeadToHead(ctx, teamA, teamB, last)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidInput) {
+			c.IndentedJSON(http.StatusBadRequest, gin.H{"error": "two different teams are needed"})
+			return
+		}
+		fmt.Println("head to head:", err)
+		c.IndentedJSON(http.StatusInternalServerError, gin.H{"error": "Error while fetching head to head"})
+		return
+	}
+
+	c.IndentedJSON(http.StatusOK, gin.H{"head_to_head": h2h, "meta": domain.CacheMetas(ctx)})
+}
+
+// teamID reads a team query parameter as an api-sports id or a team name.
+func (tc *TeamController) teamID(ctx context.Context, value, league string) (int, error) {
+	if value == "" {
+		return 0, domain.ErrTeamNotFound
+	}
+	if id, err := strconv.Atoi(value); err == nil {
+		return id, nil
+	}
+	return tc.resolver.ResolveID(ctx, value, league)
 }
 
 func (tc *TeamController) AddTeam(c *gin.Context) {
@@ -65,11 +149,15 @@ func (tc *TeamController) AddTeamFromAPI(c *gin.Context) {
 func (tc *TeamController) CacheTeams(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	leagues := []int{363, 39} // ETH and EPL
-	seasons := []int{2021, 2022, 2023}
+	var leagueIDs []int
+	seasons := map[string][]int{}
+
+	for _, league := range tc.leagues.All() {
+		leagueID := league.APISportsID
+		leagueIDs = append(leagueIDs, leagueID)
+		seasons[league.Code] = league.Seasons
 
-	for _, leagueID := range leagues {
-		for _, season := range seasons {
+		for _, season := range league.Seasons {
 			err := tc.teamUsecase.FetchAndCacheTeams(ctx, leagueID, season)
 			if err != nil {
 				c.IndentedJSON(http.StatusInternalServerError, gin.H{
@@ -81,8 +169,8 @@ func (tc *TeamController) CacheTeams(c *gin.Context) {
 	}
 
 	c.IndentedJSON(http.StatusOK, gin.H{
-		"message": "Teams cached successfully for both leagues",
-		"leagues": []int{363, 39},
+		"message": "Teams cached successfully for all leagues",
+		"leagues": leagueIDs,
 		"seasons": seasons,
 	})
 }
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// telegramReplyTimeout bounds the work on one update, LLM calls included.
+const telegramReplyTimeout = time.Minute
+
+type TelegramController struct {
+	bot    *usecase.TelegramBot
+	secret string
+}
+
+func NewTelegramController(bot *usecase.TelegramBot, secret string) *TelegramController {
+	return &TelegramController{bot: bot, secret: secret}
+}
+
+// Webhook receives updates from the Bot API. Only requests carrying the
+// secret token set with setWebhook are accepted; without a configured
+// secret every update is refused. It acknowledges at once and replies in
+// the background, since the Bot API redelivers updates that are not
+// acknowledged quickly.
+func (tc *TelegramController) Webhook(c *gin.Context) {
+	if tc.secret == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Telegram-Bot-Api-Secret-Token")), []byte(tc.secret)) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid secret token"})
+		return
+	}
+
+	var update domain.TelegramUpdate
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid update"})
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telegramReplyTimeout)
+		defer cancel()
+		if err := tc.bot.HandleUpdate(ctx, update); err != nil {
+			fmt.Printf("telegram: could not reply to update %d: %v\n", update.UpdateID, err)
+		}
+	}()
+
+	c.Status(http.StatusOK)
+}
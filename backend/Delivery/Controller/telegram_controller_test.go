@@ -0,0 +1,314 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	controller "github.com/abrshodin/ethio-fb-backend/Delivery/Controller"
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	testBotToken  = "123:test"
+	testBotSecret = "s3cret"
+	testChatID    = 42
+)
+
+// botEnv is a TelegramBot wired to a fake Bot API, fake football data and
+// in-memory sessions and follows, served through the webhook handler.
+type botEnv struct {
+	router  *gin.Engine
+	server  *fake.TelegramServer
+	api     *fake.APIService
+	follows *memoryFollows
+	convs   *memoryConversations
+	updates int
+}
+
+func newBotEnv(t *testing.T) *botEnv {
+	t.Helper()
+
+	leagues, err := infrastructure.LoadLeagueRegistry("../../config/leagues.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := infrastructure.LoadTeamAliases("../../config/team_aliases.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range aliases {
+		if aliases[i].Name == "Kedus Giorgis" {
+			aliases[i].ID = 9001
+		}
+	}
+	teams := usecase.NewTeamResolver(nil, aliases)
+
+	server := fake.NewTelegramServer(testBotToken)
+	bot := httptest.NewServer(server)
+	t.Cleanup(bot.Close)
+
+	env := &botEnv{
+		server:  server,
+		api:     fake.NewAPIService(),
+		follows: &memoryFollows{},
+		convs:   &memoryConversations{sessions: map[string]*domain.Conversation{}},
+	}
+
+	rules := usecase.NewRuleIntentParser(teams, leagues)
+	conversations := usecase.NewConversationUsecase(usecase.NewParseIntentUsecase(rules), rules, env.convs, time.Hour)
+	intents := usecase.NewIntentRegistry(leagues, testSeasons{})
+	echo := usecase.IntentHandlerFunc(func(ctx context.Context, req usecase.IntentRequest, answer *domain.AnswerContext) error {
+		answer.ContextData["data"] = req.Intent.Teams
+		return nil
+	})
+	intents.Register("fixture", echo)
+	intents.Register("table", echo)
+	standings := usecase.NewStandingsUsecase(fake.NewStandingsRepo(env.api), nil, leagues, teams)
+
+	tg := usecase.NewTelegramBot(
+		infrastructure.NewTelegramClient(bot.URL, testBotToken),
+		conversations,
+		intents,
+		usecase.NewAnswerUseCase(echoComposer{}),
+		standings,
+		liveFixtures{api: env.api},
+		env.follows,
+		teams,
+		leagues,
+		testSeasons{},
+	)
+
+	gin.SetMode(gin.TestMode)
+	env.router = gin.New()
+	env.router.POST("/telegram/webhook", controller.NewTelegramController(tg, testBotSecret).Webhook)
+	return env
+}
+
+// send posts text as a webhook update and waits for the bot's reply.
+func (e *botEnv) send(t *testing.T, text string) fake.TelegramMessage {
+	t.Helper()
+
+	e.updates++
+	sent := len(e.server.Sent())
+	update := domain.TelegramUpdate{
+		UpdateID: e.updates,
+		Message:  &domain.TelegramMessage{MessageID: e.updates, Chat: domain.TelegramChat{ID: testChatID, Type: "private"}, Text: text},
+	}
+	body, _ := json.Marshal(update)
+	req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", bytes.NewReader(body))
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", testBotSecret)
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("webhook status = %d, want 200", rec.Code)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if messages := e.server.Sent(); len(messages) > sent {
+			return messages[sent]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no reply to %q", text)
+	return fake.TelegramMessage{}
+}
+
+func TestTelegramWebhookRejectsForgedUpdates(t *testing.T) {
+	env := newBotEnv(t)
+
+	for _, secret := range []string{"", "wrong"} {
+		req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(`{"update_id":1}`))
+		if secret != "" {
+			req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		}
+		rec := httptest.NewRecorder()
+		env.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("secret %q: status = %d, want 401", secret, rec.Code)
+		}
+	}
+}
+
+func TestTelegramTable(t *testing.T) {
+	env := newBotEnv(t)
+
+	reply := env.send(t, "/table eth")
+	if reply.ChatID != testChatID || reply.ParseMode != "Markdown" {
+		t.Errorf("reply to chat %d with parse mode %q", reply.ChatID, reply.ParseMode)
+	}
+	for _, want := range []string{"Ethiopian Premier League", "```", "Saint George"} {
+		if !strings.Contains(reply.Text, want) {
+			t.Errorf("table reply lacks %q:\n%s", want, reply.Text)
+		}
+	}
+
+	if reply := env.send(t, "/table XYZ"); !strings.Contains(reply.Text, `league "XYZ"`) {
+		t.Errorf("unknown league reply: %s", reply.Text)
+	}
+}
+
+func TestTelegramLive(t *testing.T) {
+	env := newBotEnv(t)
+
+	if reply := env.send(t, "/live"); reply.Text != "No matches in play right now." {
+		t.Errorf("live reply without matches: %s", reply.Text)
+	}
+
+	home, away := 2, 1
+	env.api.SetLive("ETH", []domain.PrevFixtures{{
+		FixtureID: 1,
+		HomeTeam:  domain.MTeam{ID: 1, Name: "Saint George"},
+		AwayTeam:  domain.MTeam{ID: 2, Name: "Ethiopian Coffee"},
+		Goals:     domain.Goals{Home: &home, Away: &away},
+		Status:    domain.Status{Short: "2H", Elapsed: 67},
+	}})
+	if reply := env.send(t, "/live ETH"); !strings.Contains(reply.Text, "Saint George 2-1 Ethiopian Coffee (67')") {
+		t.Errorf("live reply: %s", reply.Text)
+	}
+}
+
+func TestTelegramFollow(t *testing.T) {
+	env := newBotEnv(t)
+
+	reply := env.send(t, "/follow kidus giorgis")
+	if !strings.Contains(reply.Text, "You now follow Kedus Giorgis") {
+		t.Errorf("follow reply: %s", reply.Text)
+	}
+	if got := env.follows.list(); len(got) != 1 || got[0] != "tg:42/9001" {
+		t.Errorf("follows = %v, want [tg:42/9001]", got)
+	}
+
+	if reply := env.send(t, "/follow"); !strings.Contains(reply.Text, "Which team?") {
+		t.Errorf("follow without a team: %s", reply.Text)
+	}
+	if reply := env.send(t, "/unfollow kidus giorgis"); !strings.Contains(reply.Text, "no longer follow") {
+		t.Errorf("unfollow reply: %s", reply.Text)
+	}
+	if got := env.follows.list(); len(got) != 0 {
+		t.Errorf("follows after unfollow = %v", got)
+	}
+}
+
+func TestTelegramMarkdownFallback(t *testing.T) {
+	env := newBotEnv(t)
+	env.server.RejectMarkdown = true
+
+	reply := env.send(t, "/help")
+	if reply.ParseMode != "" {
+		t.Errorf("parse mode = %q, want plain text after the Markdown was rejected", reply.ParseMode)
+	}
+	if !strings.Contains(reply.Text, "/table") {
+		t.Errorf("help reply: %s", reply.Text)
+	}
+}
+
+func TestTelegramFollowUpKeepsContext(t *testing.T) {
+	env := newBotEnv(t)
+
+	first := env.send(t, "When do Kedus Giorgis play next?")
+	if first.Text != "fixture: [Kedus Giorgis]" {
+		t.Fatalf("first answer = %q", first.Text)
+	}
+	second := env.send(t, "what about fasil?")
+	if second.Text != "fixture: [Fasil Ketema]" {
+		t.Errorf("follow-up answer = %q, want the fixture topic carried over", second.Text)
+	}
+
+	conv, err := env.convs.GetConversation(context.Background(), "tg:42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conv.Turns) != 2 {
+		t.Errorf("session tg:42 has %d turns, want 2", len(conv.Turns))
+	}
+}
+
+type testSeasons struct{ usecase.ISeasonCalendar }
+
+func (testSeasons) CurrentSeason(ctx context.Context, league string) (int, error) { return 2024, nil }
+
+type liveFixtures struct {
+	usecase.IFixturesUsecase
+	api *fake.APIService
+}
+
+func (f liveFixtures) GetLiveMatches(ctx context.Context, league string) (*[]domain.PrevFixtures, error) {
+	return f.api.LiveFixtures(ctx, league)
+}
+
+// echoComposer answers with the topic and teams it was asked about.
+type echoComposer struct{}
+
+func (echoComposer) ComposeAnswer(answer domain.AnswerContext) (*domain.Answer, error) {
+	return &domain.Answer{Markdown: fmt.Sprintf("%s: %v", answer.Topic, answer.ContextData["data"])}, nil
+}
+
+type memoryConversations struct {
+	mu       sync.Mutex
+	sessions map[string]*domain.Conversation
+}
+
+func (m *memoryConversations) GetConversation(ctx context.Context, id string) (*domain.Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	conv, ok := m.sessions[id]
+	if !ok {
+		return nil, domain.ErrConversationNotFound
+	}
+	copied := *conv
+	copied.Turns = append([]domain.ConversationTurn{}, conv.Turns...)
+	return &copied, nil
+}
+
+func (m *memoryConversations) SaveConversation(ctx context.Context, conv *domain.Conversation, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *conv
+	m.sessions[conv.ID] = &copied
+	return nil
+}
+
+type memoryFollows struct {
+	usecase.IFollowUsecase
+	mu      sync.Mutex
+	follows []string
+}
+
+func (m *memoryFollows) Follow(ctx context.Context, userID string, teamID int, notify bool) (*domain.FollowedTeam, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.follows = append(m.follows, fmt.Sprintf("%s/%d", userID, teamID))
+	return &domain.FollowedTeam{TeamID: fmt.Sprint(teamID), Notify: notify}, nil
+}
+
+func (m *memoryFollows) Unfollow(ctx context.Context, userID string, teamID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := fmt.Sprintf("%s/%d", userID, teamID)
+	for i, f := range m.follows {
+		if f == key {
+			m.follows = append(m.follows[:i], m.follows[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrFollowNotFound
+}
+
+func (m *memoryFollows) list() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string{}, m.follows...)
+}
@@ -5,6 +5,7 @@ import (
 
 	controller "github.com/abrshodin/ethio-fb-backend/Delivery/Controller"
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
 	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-contrib/cors"
@@ -23,6 +24,12 @@ func NewRouter(fixtureUC usecase.FixtureUsecase, newsUC *usecase.NewsUseCase) *g
 		MaxAge:           7 * 24 * time.Hour,
 	}))
 
+	// Collect the cache metadata of every read made while serving a request
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(domain.WithCacheMetaCollector(c.Request.Context()))
+		c.Next()
+	})
+
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "pong",
@@ -43,6 +50,12 @@ func NewRouter(fixtureUC usecase.FixtureUsecase, newsUC *usecase.NewsUseCase) *g
 			return
 		}
 
+		opts, err := ethiotime.ParseOptions(c.Query("calendar"), c.Query("tz"), c.Query("lang"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
 		fixtures, err := fixtureUC.GetFixtures(
 			c.Request.Context(), // Pass context
 			league,
@@ -60,7 +73,13 @@ func NewRouter(fixtureUC usecase.FixtureUsecase, newsUC *usecase.NewsUseCase) *g
 			fixtures = []domain.Fixture{}
 		}
 
-		c.JSON(http.StatusOK, gin.H{"fixtures": fixtures})
+		if opts.IsSet() {
+			for i := range fixtures {
+				fixtures[i].DateLocal = opts.FormatTimestamp(fixtures[i].DateUTC)
+			}
+		}
+
+		c.JSON(http.StatusOK, gin.H{"fixtures": fixtures, "meta": domain.CacheMetas(c.Request.Context())})
 	})
 
 	return router
@@ -70,6 +89,11 @@ func RegisterNewsRoutes(router *gin.Engine, newsHandler *controller.NewsControll
 
 	newsRouter := router.Group("/news")
 
+	newsRouter.GET("", newsHandler.Feed)
+	newsRouter.GET("/feed.rss", newsHandler.FeedRSS)
+	newsRouter.GET("/feed.atom", newsHandler.FeedAtom)
+	newsRouter.GET("/feed.json", newsHandler.FeedJSON)
+	newsRouter.GET("/:id", newsHandler.Article)
 	newsRouter.GET("/pastMatches", newsHandler.GetNews)
 	newsRouter.GET("/standings", newsHandler.GetStandingNews)
 	newsRouter.GET("/futureMatches", newsHandler.GetFutureNews)
@@ -86,10 +110,18 @@ func RegisterRoute(router *gin.Engine, handler *controller.IntentController, ans
 func RegisterTeamRoutes(r *gin.Engine, handler *controller.TeamController) {
 	team := r.Group("team")
 	{
+		team.GET("/resolve", handler.ResolveTeam)
 		team.GET("/:id/bio", handler.GetTeam)
 		team.POST("/create", handler.AddTeam)
 		team.POST("/cache", handler.CacheTeams)
 	}
+	r.GET("/api/h2h", handler.HeadToHead)
+}
+
+func RegisterPlayerRoutes(r *gin.Engine, handler *controller.PlayerController) {
+	r.GET("/team/:id/squad", handler.Squad)
+	r.GET("/players/:id", handler.Player)
+	r.GET("/api/topscorers", handler.TopScorers)
 }
 
 func RegisterAPISercice(r *gin.Engine, handler *controller.FixturesController) {
@@ -98,6 +130,8 @@ func RegisterAPISercice(r *gin.Engine, handler *controller.FixturesController) {
 	{
 		api.GET("/previous-fixtures", handler.PreviousMatchHistory)
 		api.GET("/live", handler.LiveFixtures)
+		api.GET("/live/stream", handler.LiveStream)
+		api.GET("/fixtures/:id", handler.MatchDetail)
 	}
 
 }
@@ -106,5 +140,27 @@ func RegisterStandingsRoutes(r *gin.Engine, handler *controller.StandingsControl
 	standings := r.Group("api/standings")
 	{
 		standings.GET("", handler.GetStandings)
+		standings.GET("/history", handler.History)
 	}
 }
+
+func RegisterFollowRoutes(r *gin.Engine, handler *controller.FollowController) {
+	me := r.Group("me", handler.RequireUser)
+	{
+		me.GET("/follows", handler.List)
+		me.POST("/follows/:teamId", handler.Follow)
+		me.DELETE("/follows/:teamId", handler.Unfollow)
+		me.GET("/feed", handler.Feed)
+	}
+}
+
+func RegisterAdminRoutes(r *gin.Engine, handler *controller.AdminController) {
+	admin := r.Group("admin", handler.RequireToken)
+	{
+		admin.GET("/quota", handler.Quota)
+	}
+}
+
+func RegisterTelegramRoutes(r *gin.Engine, handler *controller.TelegramController) {
+	r.POST("/telegram/webhook", handler.Webhook)
+}
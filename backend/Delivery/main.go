@@ -1,15 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"net/http"
 
 	controller "github.com/abrshodin/ethio-fb-backend/Delivery/Controller"
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	routers "github.com/abrshodin/ethio-fb-backend/Delivery/Router"
 	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
 	repository "github.com/abrshodin/ethio-fb-backend/Repository"
 	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
 	"github.com/joho/godotenv"
 	"os"
+	"strconv"
+	"time"
 )
 
 func main() {
@@ -22,58 +29,279 @@ func main() {
 	}
 
 
+	// Upstream mode: live (default), record or replay HTTP to disk, or fake in-memory data
+	upstreamMode := os.Getenv("UPSTREAM_MODE")
+	if upstreamMode == infrastructure.ReplayRecord || upstreamMode == infrastructure.ReplayOnly {
+		dir := os.Getenv("UPSTREAM_RECORDINGS")
+		if dir == "" {
+			dir = "testdata/recordings"
+		}
+		transport, err := infrastructure.NewReplayTransport(upstreamMode, dir, http.DefaultTransport,
+			"v3.football.api-sports.io",
+			"www.thesportsdb.com",
+			"generativelanguage.googleapis.com",
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		http.DefaultTransport = transport
+	}
+
+	// League registry
+	leaguesPath := os.Getenv("LEAGUES_CONFIG")
+	if leaguesPath == "" {
+		leaguesPath = "config/leagues.json"
+	}
+	leagues, err := infrastructure.LoadLeagueRegistry(leaguesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Redis & Team setup
 	redisClient := infrastructure.RedisConnect()
 	teamRepo := repository.NewTeamRepo(redisClient)
+	swrCache := repository.NewSWRCache(redisClient)
+
+	// Shared api-sports client with quota accounting
+	quotaRepo := repository.NewQuotaRepo(redisClient)
+	apiClient := infrastructure.NewAPISportsClient(
+		os.Getenv("API_SPORTS_API_KEY"),
+		quotaRepo,
+		envInt("API_QUOTA_RESERVE", 10),
+		envInt("API_QUOTA_LOW_RESERVE", 40),
+	)
+	quotaUC := usecase.NewQuotaUsecase(quotaRepo, "api-sports")
+	adminHandler := controller.NewAdminController(quotaUC, os.Getenv("ADMIN_TOKEN"))
 	
-	apiService := infrastructure.NewAPIService()
-	prevRepo := repository.NewPrevFixturesRepo(redisClient)
-	prevUC := usecase.NewFixturesUsecase(apiService, prevRepo)
+	apiService := infrastructure.NewAPIService(leagues, apiClient)
 
-	teamUsecase := usecase.NewTeamUsecase(teamRepo, apiService)
-	teamHandler := controller.NewTeamController(teamUsecase)
-	historyHandler := controller.NewFixturesController(prevUC)
+	// api-sports first, TheSportsDB when it fails or runs out of quota
+	var provider domain.FootballDataProvider = infrastructure.NewFailoverProvider(
+		infrastructure.NewAPISportsProvider(leagues, apiClient),
+		infrastructure.NewSportsDBProvider(leagues, os.Getenv("SPORTSDB_API_KEY")),
+	)
+	var fakeAPI *fake.APIService
+	if upstreamMode == "fake" {
+		fakeAPI = fake.NewAPIService()
+		apiService, provider = fakeAPI, fakeAPI
+	}
+	prevRepo := repository.NewPrevFixturesRepo(redisClient, swrCache)
+	seasonRepo := repository.NewSeasonRepo(redisClient)
+	seasonCalendar := usecase.NewSeasonCalendar(apiService, prevRepo, seasonRepo, leagues)
+	jobRepo := repository.NewJobRepo(redisClient)
+	prevUC := usecase.NewFixturesUsecase(apiService, provider, prevRepo, swrCache, seasonCalendar, jobRepo)
 
-	fixtureRepo := repository.NewAPIRepo(redisClient)
-	fixtureUC := usecase.NewFixtureUsecase(fixtureRepo, fixtureRepo)
+	// Team names and aliases, seeded from config and extended as teams are seen upstream
+	aliasesPath := os.Getenv("TEAM_ALIASES_CONFIG")
+	if aliasesPath == "" {
+		aliasesPath = "config/team_aliases.json"
+	}
+	teamAliases, err := infrastructure.LoadTeamAliases(aliasesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	teamResolver := usecase.NewTeamResolver(repository.NewTeamAliasRepo(redisClient), teamAliases)
+	if err := teamResolver.Load(context.Background()); err != nil {
+		fmt.Println("could not load registered team aliases:", err)
+	}
+
+	teamUsecase := usecase.NewTeamUsecase(teamRepo, swrCache, teamResolver, apiService, leagues)
+	teamHandler := controller.NewTeamController(teamUsecase, leagues, teamResolver)
+	playerUC := usecase.NewPlayerUsecase(apiService, swrCache, leagues)
+	playerHandler := controller.NewPlayerController(playerUC, teamResolver, leagues, seasonCalendar)
+	livePollInterval, err := time.ParseDuration(os.Getenv("LIVE_POLL_INTERVAL"))
+	if err != nil || livePollInterval <= 0 {
+		livePollInterval = time.Minute
+	}
+	liveHub := usecase.NewLiveHub(prevUC, livePollInterval)
+	historyHandler := controller.NewFixturesController(prevUC, leagues, seasonCalendar, liveHub)
+
+	fixtureRepo := repository.NewAPIRepo(swrCache, leagues, apiClient)
+	fixtureUC := usecase.NewFixtureUsecase(fixtureRepo)
 
 	// News setup
-	eventRepo := repository.NewEventRepository()
-	newsUC := usecase.NewNewsUseCase(eventRepo)
+	newsLeague := leagues.Default()
+	var eventRepo usecase.EventRepository = repository.NewEventRepository(newsLeague.SportsDBID)
+	if fakeAPI != nil {
+		eventRepo = fake.NewEventRepository()
+	}
+	reportsPath := os.Getenv("REPORT_TEMPLATES_CONFIG")
+	if reportsPath == "" {
+		reportsPath = "config/report_templates.json"
+	}
+	reportTemplates, err := infrastructure.LoadReportTemplates(reportsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	reportWriter, err := usecase.NewReportWriter(reportTemplates)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Standings setup
-	standingsRepo := repository.NewStandingsRepo(redisClient)
-	standingsUC := usecase.NewStandingsUsecase(standingsRepo)
-	standingsHandler := controller.NewStandingsController(standingsUC)
+	standingsRepo := repository.NewStandingsRepo(redisClient, swrCache, provider)
+	if fakeAPI != nil {
+		standingsRepo = fake.NewStandingsRepo(fakeAPI)
+	}
+	newsUC := usecase.NewNewsUseCase(eventRepo, repository.NewNewsRepo(redisClient), standingsRepo, teamResolver, reportWriter, &newsLeague)
+	standingsUC := usecase.NewStandingsUsecase(standingsRepo, prevUC, leagues, teamResolver)
+	standingsHandler := controller.NewStandingsController(standingsUC, leagues, seasonCalendar)
+
+	// Follows setup
+	followRepo := repository.NewFollowRepo(redisClient)
+	followUC := usecase.NewFollowUsecase(followRepo, teamUsecase, fixtureUC, standingsUC, seasonCalendar, leagues)
+	followHandler := controller.NewFollowController(followUC)
+
+	// Telegram bot (disabled unless TELEGRAM_BOT_TOKEN is set); TELEGRAM_API_URL=fake
+	// runs a local fake Bot API on TELEGRAM_FAKE_ADDR instead of using Telegram
+	var telegramClient *infrastructure.TelegramClient
+	if token := os.Getenv("TELEGRAM_BOT_TOKEN"); token != "" {
+		// the secret is all that tells the Bot API's updates from forged ones
+		if os.Getenv("TELEGRAM_WEBHOOK_SECRET") == "" {
+			log.Fatal("TELEGRAM_WEBHOOK_SECRET must be set to run the Telegram bot")
+		}
+		apiURL := os.Getenv("TELEGRAM_API_URL")
+		if apiURL == "fake" {
+			addr := os.Getenv("TELEGRAM_FAKE_ADDR")
+			if addr == "" {
+				addr = "127.0.0.1:8081"
+			}
+			go func() {
+				log.Fatal(http.ListenAndServe(addr, fake.NewTelegramServer(token)))
+			}()
+			apiURL = "http://" + addr
+		}
+		telegramClient = infrastructure.NewTelegramClient(apiURL, token)
+	}
+
+	// Notifications for followed teams (disabled unless NOTIFIER is set or the
+	// Telegram bot is enabled, which alerts the chats that follow a team).
+	// Live scores are followed in the kickoff windows the ingestion worker
+	// saves, so INGESTION_WORKER=on must be set on at least one replica.
+	var notifier domain.Notifier
+	switch os.Getenv("NOTIFIER") {
+	case "webhook":
+		notifier = infrastructure.NewWebhookNotifier(os.Getenv("NOTIFY_WEBHOOK_URL"), os.Getenv("NOTIFY_WEBHOOK_SECRET"))
+	case "log":
+		if notifier, err = infrastructure.NewLogNotifier(os.Getenv("NOTIFY_LOG_PATH")); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if telegramClient != nil {
+		notifier = infrastructure.NewTelegramNotifier(telegramClient, notifier)
+	}
+	if notifier != nil {
+		dedupRepo := repository.NewDedupRepo(redisClient, "notif")
+		dispatcher := usecase.NewNotificationDispatcher(liveHub, followRepo, dedupRepo, notifier, leagues, jobRepo)
+		go dispatcher.Run(context.Background())
+	}
+
+	// Background ingestion (disabled unless INGESTION_WORKER=on); replicas
+	// running it share the work through leases in Redis
+	if os.Getenv("INGESTION_WORKER") == "on" {
+		worker := usecase.NewIngestionWorker(jobRepo, leagues, seasonCalendar, fixtureUC, prevUC, standingsRepo, teamUsecase, livePollInterval)
+		go worker.Run(context.Background())
+	}
 
 	// News route
-	newsHandler := controller.NewNewsController(newsUC)
+	// Feeds link back to the API at PUBLIC_BASE_URL, not at whatever host a
+	// request claims, since their responses are publicly cacheable
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		publicBaseURL = "http://localhost:8080"
+	}
+	newsHandler := controller.NewNewsController(newsUC, leagues, publicBaseURL)
 	
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	answerComposer := infrastructure.NewAIAnswerComposer(apiKey)
+	// LLM backend: Gemini by default, or any OpenAI-compatible server (OpenAI, Ollama, ...)
+	var answerComposer domain.AnswerComposer
+	var intentParser usecase.IntentParser
+	switch os.Getenv("LLM_PROVIDER") {
+	case "openai", "ollama":
+		baseURL := os.Getenv("LLM_BASE_URL")
+		if baseURL == "" {
+			baseURL = "http://localhost:11434/v1"
+		}
+		model := os.Getenv("LLM_MODEL")
+		if model == "" {
+			model = "llama3.1"
+		}
+		llm := infrastructure.NewOpenAIChatClient(baseURL, os.Getenv("LLM_API_KEY"), model)
+		answerComposer = infrastructure.NewOpenAIAnswerComposer(llm)
+		intentParser = infrastructure.NewOpenAIIntentParser(llm, teamResolver, leagues)
+	default:
+		apiKey := os.Getenv("GEMINI_API_KEY")
+		answerComposer = infrastructure.NewAIAnswerComposer(apiKey)
+		if apiKey != "" {
+			intentParser = infrastructure.NewAIIntentParser(apiKey, teamResolver, leagues)
+		}
+	}
+
+	// Intent parsing: LLM with rule fallback (default), rules first, or rules only
+	ruleParser := usecase.NewRuleIntentParser(teamResolver, leagues)
+	switch os.Getenv("INTENT_PARSER") {
+	case "rules":
+		intentParser = nil
+	case "rules-first":
+		intentParser = usecase.NewLayeredIntentParser(ruleParser, intentParser, true)
+	}
+	switch p := intentParser.(type) {
+	case nil:
+		intentParser = ruleParser
+	case *usecase.LayeredIntentParser:
+	default:
+		intentParser = usecase.NewLayeredIntentParser(ruleParser, p, false)
+	}
 	answerUseCase := usecase.NewAnswerUseCase(answerComposer)
 	answerController := controller.NewAnswerController(answerUseCase)
 
-	intentParser := infrastructure.NewAIIntentParser(apiKey)
 	intentUsecase := usecase.NewParseIntentUsecase(intentParser)
-	intentController := controller.NewIntentController(
-														intentUsecase,
-														standingsHandler,
-														newsHandler,
-														teamHandler,
-														answerController,
-														prevUC,
-													)
-	
+
+	// Conversation sessions let follow-ups reuse the slots of earlier queries
+	conversationTTL, err := time.ParseDuration(os.Getenv("CONVERSATION_TTL"))
+	if err != nil || conversationTTL <= 0 {
+		conversationTTL = 30 * time.Minute
+	}
+	conversationUC := usecase.NewConversationUsecase(intentUsecase, ruleParser, repository.NewConversationRepo(redisClient), conversationTTL)
+
+	// Each intent topic is answered by its own handler
+	intentRegistry := usecase.NewIntentRegistry(leagues, seasonCalendar)
+	intentRegistry.Register("fixture", usecase.NewFixtureIntentHandler(fixtureUC, teamResolver))
+	intentRegistry.Register("table", usecase.NewStandingsIntentHandler(standingsUC))
+	intentRegistry.Register("news", usecase.NewNewsIntentHandler(newsUC))
+	intentRegistry.Register("compare", usecase.NewCompareIntentHandler(teamUsecase, teamResolver))
+	intentRegistry.Register("fact", usecase.NewFactIntentHandler(teamUsecase, teamResolver))
+	intentRegistry.Register("player", usecase.NewPlayerIntentHandler(playerUC, teamResolver))
+	intentController := controller.NewIntentController(conversationUC, intentRegistry, answerUseCase, teamUsecase, leagues, seasonCalendar)
 
 	// Router
 	router := routers.NewRouter(fixtureUC, newsUC)
 	routers.RegisterTeamRoutes(router, teamHandler)
+	routers.RegisterPlayerRoutes(router, playerHandler)
 	routers.RegisterAPISercice(router, historyHandler)
 	routers.RegisterStandingsRoutes(router, standingsHandler)
 	routers.RegisterNewsRoutes(router, newsHandler)
 	routers.RegisterRoute(router, intentController, answerController)
+	routers.RegisterFollowRoutes(router, followHandler)
+	routers.RegisterAdminRoutes(router, adminHandler)
+
+	if telegramClient != nil {
+		bot := usecase.NewTelegramBot(telegramClient, conversationUC, intentRegistry, answerUseCase, standingsUC, prevUC, followUC, teamResolver, leagues, seasonCalendar)
+		routers.RegisterTelegramRoutes(router, controller.NewTelegramController(bot, os.Getenv("TELEGRAM_WEBHOOK_SECRET")))
+		if webhookURL := os.Getenv("TELEGRAM_WEBHOOK_URL"); webhookURL != "" {
+			if err := telegramClient.SetWebhook(context.Background(), webhookURL, os.Getenv("TELEGRAM_WEBHOOK_SECRET")); err != nil {
+				fmt.Println("could not set telegram webhook:", err)
+			}
+		}
+	}
 
 	
 	router.Run()
 }
+
+// envInt reads an integer environment variable, falling back to def.
+func envInt(name string, def int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil {
+		return n
+	}
+	return def
+}
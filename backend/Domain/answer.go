@@ -32,12 +32,20 @@ type Answer struct {
 	Freshness      time.Time       `json:"freshness"`
 }
 
+// LocalizedDate pairs an upstream UTC timestamp with the way fans read it.
+type LocalizedDate struct {
+	Label string `json:"label"`
+	UTC   string `json:"utc"`
+	Local string `json:"local"`
+}
+
 type AnswerContext struct {
 	Topic       string 
 	Language    string
 	Source      string
 	Freshness   time.Time
 	ContextData map[string]interface{}
+	Dates       []LocalizedDate
 }
 
 type AnswerComposer interface {
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type cacheMetaKey struct{}
+
+// CacheMetaCollector gathers the CacheMeta of every cache read made while
+// serving one request, so handlers can report how fresh their data is.
+type CacheMetaCollector struct {
+	mu    sync.Mutex
+	metas []CacheMeta
+}
+
+// WithCacheMetaCollector returns a context that collects cache metadata.
+func WithCacheMetaCollector(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheMetaKey{}, &CacheMetaCollector{})
+}
+
+// RecordCacheMeta adds meta to the request's collector, if there is one.
+func RecordCacheMeta(ctx context.Context, meta CacheMeta) {
+	c, ok := ctx.Value(cacheMetaKey{}).(*CacheMetaCollector)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	c.metas = append(c.metas, meta)
+	c.mu.Unlock()
+}
+
+// CacheMetas returns everything recorded on ctx so far.
+func CacheMetas(ctx context.Context) []CacheMeta {
+	c, ok := ctx.Value(cacheMetaKey{}).(*CacheMetaCollector)
+	if !ok {
+		return []CacheMeta{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]CacheMeta{}, c.metas...)
+}
+
+// OldestFreshness returns the fetch time of the oldest data recorded on ctx
+// and the source it came from ("api" if any piece was fetched just now).
+func OldestFreshness(ctx context.Context) (time.Time, string, bool) {
+	var oldest time.Time
+	source := "cache"
+	found := false
+
+	for _, m := range CacheMetas(ctx) {
+		t, err := time.Parse(time.RFC3339, m.FreshnessTS)
+		if err != nil {
+			continue
+		}
+		if !found || t.Before(oldest) {
+			oldest = t
+		}
+		if m.Source == "api" {
+			source = "api"
+		}
+		found = true
+	}
+	return oldest, source, found
+}
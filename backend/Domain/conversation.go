@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ConversationTurn is one query of a conversation and what it resolved to.
+type ConversationTurn struct {
+	Text   string    `json:"text"`
+	Intent Intent    `json:"intent"`
+	Answer string    `json:"answer,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// Conversation holds the recent turns of an /intent/parse session so
+// follow-up questions can reuse the slots of earlier ones.
+type Conversation struct {
+	ID    string             `json:"id"`
+	Turns []ConversationTurn `json:"turns"`
+}
+
+// LastIntent returns the intent of the most recent turn, or nil.
+func (c *Conversation) LastIntent() *Intent {
+	if c == nil || len(c.Turns) == 0 {
+		return nil
+	}
+	return &c.Turns[len(c.Turns)-1].Intent
+}
+
+type IConversationRepo interface {
+	GetConversation(ctx context.Context, id string) (*Conversation, error)
+	SaveConversation(ctx context.Context, conv *Conversation, ttl time.Duration) error
+}
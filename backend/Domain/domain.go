@@ -16,6 +16,7 @@ type Fixture struct {
 	ID          string `json:"id"`
 	League      string `json:"league"`
 	DateUTC     string `json:"date_utc"` // ISO string
+	DateLocal   string `json:"date_local,omitempty"`
 	HomeName    string `json:"home_name"`
 	AwayName    string `json:"away_name"`
 	Status      string `json:"status"`
@@ -27,12 +28,35 @@ type Fixture struct {
 
 // NewsItem represents a news article
 type NewsItem struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Snippet     string `json:"snippet"`
-	Source      string `json:"source"`
-	URL         string `json:"url"`
-	PublishedAt string `json:"published_at"`
+	ID          string   `json:"id"`   // stable per story, e.g. "result-1032123"
+	Kind        string   `json:"kind"` // "result", "preview", "live" or "standings"
+	Title       string   `json:"title"`
+	Snippet     string   `json:"snippet"`
+	Source      string   `json:"source"`
+	URL         string   `json:"url"`
+	PublishedAt string   `json:"published_at"`
+	UpdatedAt   string   `json:"updated_at,omitempty"`
+	Teams       []string `json:"teams"`
+	League      string   `json:"league"`
+	Language    string   `json:"language"` // language of Title and Snippet
+	// Translations holds the title and snippet in every rendered language.
+	Translations map[string]NewsText `json:"translations,omitempty"`
+}
+
+// NewsText is the title and snippet of a news item in one language.
+type NewsText struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+// In returns the item with its title and snippet in lang, when rendered in
+// it, and without the other translations.
+func (n NewsItem) In(lang string) NewsItem {
+	if text, ok := n.Translations[lang]; ok {
+		n.Title, n.Snippet, n.Language = text.Title, text.Snippet, lang
+	}
+	n.Translations = nil
+	return n
 }
 
 // FollowedTeam represents a user's followed team
@@ -47,6 +71,7 @@ type CacheMeta struct {
 	Key         string `json:"key"`
 	Source      string `json:"source"`
 	FreshnessTS string `json:"freshness_ts"`
+	Stale       bool   `json:"stale"`
 }
 
 type APIResponse struct {
@@ -150,6 +175,7 @@ type Teams struct {
 }
 
 type MTeam struct {
+	ID   int    `json:"id,omitempty"`
 	Name string `json:"name"`
 	Logo string `json:"logo"`
 }
@@ -192,6 +218,7 @@ type Status struct {
 type PrevFixtures struct {
 	FixtureID   int    `json:"fixture_id"`
 	Date        string `json:"date"`
+	DateLocal   string `json:"date_local,omitempty"`
 	Venue       string `json:"venue"`
 	League      string `json:"league"`
 	LeagueRound string `json:"round"`
@@ -226,6 +253,12 @@ type Event struct {
 	StrStatus         string `json:"strStatus"`
 	StrHomeTeamBadge  string `json:"strHomeTeamBadge"`
 	StrAwayTeamBadge  string `json:"strAwayTeamBadge"`
+	StrTimestamp      string `json:"strTimestamp,omitempty"`
+	StrVenue          string `json:"strVenue,omitempty"`
+	IntRound          string `json:"intRound,omitempty"`
+	// goal minutes and scorers, "12':Name;45+1':Name;", when TheSportsDB has them
+	StrHomeGoalDetails string `json:"strHomeGoalDetails,omitempty"`
+	StrAwayGoalDetails string `json:"strAwayGoalDetails,omitempty"`
 }
 
 type LeaguePoint struct {
@@ -266,6 +299,7 @@ type TeamStanding struct {
 	Wins          int          `json:"wins"`
 	Losses        int          `json:"losses"`
 	Draws         int          `json:"draws"`
+	Form          string       `json:"form"`
 	All           MatchStats   `json:"all"`
 }
 
@@ -290,6 +324,7 @@ type GoalStats struct {
 
 type Standing struct {
 	Rank          int    `json:"rank"`
+	TeamID        int    `json:"teamId,omitempty"`
 	TeamName      string `json:"teamName"`
 	TeamLogo      string `json:"teamLogo"`
 	Points        int    `json:"points"`
@@ -297,7 +332,10 @@ type Standing struct {
 	Wins          int    `json:"wins"`
 	Losses        int    `json:"losses"`
 	Draws         int    `json:"draws"`
+	GoalsFor      int    `json:"goalsFor"`
+	GoalsAgainst  int    `json:"goalsAgainst"`
 	GoalsDiff     int    `json:"goalsDiff"`
+	Form          string `json:"form,omitempty"` // latest result last, e.g. "WDLWW"
 }
 
 type StandingsResponse struct {
@@ -308,6 +346,7 @@ type StandingsResponse struct {
 	Season      int        `json:"season"`
 	Standings   []Standing `json:"standings"`
 	LastUpdated string     `json:"lastUpdated"`
+	Provider    string     `json:"provider,omitempty"`
 }
 
 type StatAPIResponse struct {
@@ -3,8 +3,18 @@ package domain
 import "errors"
 
 var (
-	ErrInternalServer = errors.New("internal server error")
-	ErrDuplicateFound = errors.New("duplicate key found")
-	ErrTeamNotFound   = errors.New("team not found")
-	ErrUnexpected     = errors.New("Unexpected")
+	ErrInternalServer       = errors.New("internal server error")
+	ErrDuplicateFound       = errors.New("duplicate key found")
+	ErrTeamNotFound         = errors.New("team not found")
+	ErrUnexpected           = errors.New("Unexpected")
+	ErrLeagueNotFound       = errors.New("league not found")
+	ErrSeasonNotFound       = errors.New("season not found")
+	ErrRoundNotFound        = errors.New("round not found")
+	ErrFollowNotFound       = errors.New("team is not followed")
+	ErrQuotaExhausted       = errors.New("upstream request quota exhausted")
+	ErrNoData               = errors.New("no data available from provider")
+	ErrConversationNotFound = errors.New("conversation not found")
+	ErrFixtureNotFound      = errors.New("fixture not found")
+	ErrPlayerNotFound       = errors.New("player not found")
+	ErrNewsNotFound         = errors.New("news item not found")
 )
@@ -0,0 +1,18 @@
+package domain
+
+// FeedStanding is a followed team's row in its league table.
+type FeedStanding struct {
+	League string `json:"league"`
+	Season int    `json:"season"`
+	Standing
+}
+
+// Feed is the personalised view of a user's followed teams.
+type Feed struct {
+	Teams       []Team         `json:"teams"`
+	Upcoming    []Fixture      `json:"upcoming"`
+	Results     []Fixture      `json:"results"`
+	CalledOff   []Fixture      `json:"called_off"` // postponed and cancelled
+	Standings   []FeedStanding `json:"standings"`
+	GeneratedAt string         `json:"generated_at"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+// HeadToHead is the record of two teams' previous meetings, counted from
+// TeamA's side.
+type HeadToHead struct {
+	TeamA    MTeam          `json:"team_a"`
+	TeamB    MTeam          `json:"team_b"`
+	Played   int            `json:"played"`
+	WinsA    int            `json:"wins_a"`
+	WinsB    int            `json:"wins_b"`
+	Draws    int            `json:"draws"`
+	GoalsA   int            `json:"goals_a"`
+	GoalsB   int            `json:"goals_b"`
+	Meetings []PrevFixtures `json:"meetings"` // finished meetings, latest first
+}
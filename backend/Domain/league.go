@@ -0,0 +1,62 @@
+package domain
+
+// LeagueConfig describes a competition the backend knows how to serve.
+// Entries are loaded from the league config file so new leagues can be
+// added without touching code.
+type LeagueConfig struct {
+	Code        string            `json:"code"`
+	APISportsID int               `json:"api_sports_id"`
+	SportsDBID  string            `json:"sportsdb_id"`
+	Country     string            `json:"country"`
+	Calendar    LeagueCalendar    `json:"calendar"`
+	Seasons     []int             `json:"seasons"`
+	Names       map[string]string `json:"names"` // keyed by language, e.g. "en", "am"
+	// Aliases are the other words fans use for the league, in any script
+	// ("epl", "ፕሪምየር ሊግ"). The code and names are always recognised.
+	Aliases []string `json:"aliases,omitempty"`
+	// Default marks the league served when a request names none. Without
+	// one the first configured league is the default.
+	Default bool `json:"default,omitempty"`
+	// TieBreakers orders how teams level on points are separated:
+	// "head_to_head", "goal_difference" and "goals_for".
+	TieBreakers []string `json:"tie_breakers,omitempty"`
+	// Derbies lists rival pairs by their canonical team names.
+	Derbies [][2]string `json:"derbies,omitempty"`
+}
+
+// IsDerby reports whether teamA and teamB, by canonical name, are rivals.
+func (l LeagueConfig) IsDerby(teamA, teamB string) bool {
+	for _, d := range l.Derbies {
+		if (d[0] == teamA && d[1] == teamB) || (d[0] == teamB && d[1] == teamA) {
+			return true
+		}
+	}
+	return false
+}
+
+// LeagueCalendar holds the months a season usually starts and ends in.
+type LeagueCalendar struct {
+	StartMonth int `json:"start_month"`
+	EndMonth   int `json:"end_month"`
+}
+
+// Name returns the display name for the given language, falling back to English.
+func (l LeagueConfig) Name(lang string) string {
+	if name, ok := l.Names[lang]; ok && name != "" {
+		return name
+	}
+	if name, ok := l.Names["en"]; ok && name != "" {
+		return name
+	}
+	return l.Code
+}
+
+type ILeagueRegistry interface {
+	// Resolve accepts a league code ("ETH") or a numeric api-sports id ("363").
+	Resolve(codeOrID string) (*LeagueConfig, error)
+	ByCode(code string) (*LeagueConfig, error)
+	ByAPISportsID(id int) (*LeagueConfig, error)
+	All() []LeagueConfig
+	// Default is the league used when a request does not name one.
+	Default() LeagueConfig
+}
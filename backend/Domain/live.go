@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// Live event types sent to stream subscribers.
+const (
+	LiveEventSnapshot = "snapshot"
+	LiveEventKickoff  = "kickoff"
+	LiveEventGoal     = "goal"
+	LiveEventStatus   = "status"
+	LiveEventFullTime = "full_time"
+)
+
+// LiveEvent is a change between two successive live fixture snapshots.
+type LiveEvent struct {
+	Type      string         `json:"type"`
+	League    string         `json:"league"`
+	FixtureID int            `json:"fixture_id,omitempty"`
+	Team      string         `json:"team,omitempty"` // scoring side for goal events
+	TeamID    int            `json:"team_id,omitempty"`
+	Score     string         `json:"score,omitempty"` // score right after a goal, "home-away"
+	Fixture   *PrevFixtures  `json:"fixture,omitempty"`
+	Fixtures  []PrevFixtures `json:"fixtures,omitempty"` // snapshot events only
+	At        time.Time      `json:"at"`
+}
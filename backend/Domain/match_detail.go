@@ -0,0 +1,85 @@
+package domain
+
+// MatchEvent is a goal, card, substitution or VAR decision of a match.
+type MatchEvent struct {
+	Elapsed  int         `json:"elapsed"`
+	Extra    *int        `json:"extra"`
+	Team     EventTeam   `json:"team"`
+	Player   EventPlayer `json:"player"`
+	Assist   EventAssist `json:"assist"`
+	Type     string      `json:"type"`   // "Goal", "Card", "subst" or "Var"
+	Detail   string      `json:"detail"` // "Normal Goal", "Yellow Card", "Substitution 1", ...
+	Comments *string     `json:"comments"`
+}
+
+type LineupPlayer struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Number int     `json:"number"`
+	Pos    string  `json:"pos"`
+	Grid   *string `json:"grid"`
+}
+
+type Lineup struct {
+	Team        EventTeam      `json:"team"`
+	Coach       string         `json:"coach"`
+	Formation   string         `json:"formation"`
+	StartXI     []LineupPlayer `json:"start_xi"`
+	Substitutes []LineupPlayer `json:"substitutes"`
+}
+
+// MatchStat is one team statistic of a match. Value is a number, a
+// percentage string such as "55%", or null when the provider has none.
+type MatchStat struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+type TeamMatchStats struct {
+	Team       EventTeam   `json:"team"`
+	Statistics []MatchStat `json:"statistics"`
+}
+
+// MatchDetail is a fixture with its timeline, lineups and statistics.
+type MatchDetail struct {
+	PrevFixtures
+	Events     []MatchEvent     `json:"events"`
+	Lineups    []Lineup         `json:"lineups"`
+	Statistics []TeamMatchStats `json:"statistics"`
+}
+
+// FixtureDetailAPIResponse is the api-sports /fixtures?id= response, which
+// carries events, lineups and statistics for a single fixture.
+type FixtureDetailAPIResponse struct {
+	Response []FixtureDetailMatch `json:"response"`
+}
+
+type FixtureDetailMatch struct {
+	Match
+	Events []struct {
+		Time struct {
+			Elapsed int  `json:"elapsed"`
+			Extra   *int `json:"extra"`
+		} `json:"time"`
+		Team     EventTeam   `json:"team"`
+		Player   EventPlayer `json:"player"`
+		Assist   EventAssist `json:"assist"`
+		Type     string      `json:"type"`
+		Detail   string      `json:"detail"`
+		Comments *string     `json:"comments"`
+	} `json:"events"`
+	Lineups []struct {
+		Team  EventTeam `json:"team"`
+		Coach struct {
+			Name string `json:"name"`
+		} `json:"coach"`
+		Formation string `json:"formation"`
+		StartXI   []struct {
+			Player LineupPlayer `json:"player"`
+		} `json:"startXI"`
+		Substitutes []struct {
+			Player LineupPlayer `json:"player"`
+		} `json:"substitutes"`
+	} `json:"lineups"`
+	Statistics []TeamMatchStats `json:"statistics"`
+}
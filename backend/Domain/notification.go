@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Notification types sent to followers of a team.
+const (
+	NotifyGoalScored   = "goal_scored"
+	NotifyGoalConceded = "goal_conceded"
+	NotifyKickoff      = "kickoff"
+	NotifyFullTime     = "full_time"
+)
+
+// Notification is a message for one user about one of their followed teams.
+type Notification struct {
+	UserID    string        `json:"user_id"`
+	TeamID    string        `json:"team_id"`
+	FixtureID int           `json:"fixture_id"`
+	Type      string        `json:"type"`
+	Title     string        `json:"title"`
+	Body      string        `json:"body"`
+	Fixture   *PrevFixtures `json:"fixture,omitempty"`
+	CreatedAt string        `json:"created_at"`
+}
+
+// Notifier delivers notifications to users.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// IDedupRepo remembers which keys have been handled already.
+type IDedupRepo interface {
+	// FirstSeen marks key as seen and reports whether this call was the first.
+	FirstSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Forget unmarks key, so it is seen for the first time again.
+	Forget(ctx context.Context, key string) error
+}
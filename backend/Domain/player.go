@@ -0,0 +1,94 @@
+package domain
+
+// Player is a footballer's profile.
+type Player struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Firstname   string `json:"firstname"`
+	Lastname    string `json:"lastname"`
+	Age         int    `json:"age"`
+	Nationality string `json:"nationality"`
+	Height      string `json:"height"`
+	Weight      string `json:"weight"`
+	Photo       string `json:"photo"`
+}
+
+// PlayerSeasonStats is a player's record for one team in one competition.
+type PlayerSeasonStats struct {
+	Team        MTeam  `json:"team"`
+	League      string `json:"league"`
+	Season      int    `json:"season"`
+	Position    string `json:"position"`
+	Appearances int    `json:"appearances"`
+	Minutes     int    `json:"minutes"`
+	Rating      string `json:"rating,omitempty"`
+	Goals       int    `json:"goals"`
+	Assists     int    `json:"assists"`
+	YellowCards int    `json:"yellow_cards"`
+	RedCards    int    `json:"red_cards"`
+}
+
+// PlayerProfile is a player with the season's statistics.
+type PlayerProfile struct {
+	Player
+	Statistics []PlayerSeasonStats `json:"statistics"`
+}
+
+type SquadPlayer struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Age      int    `json:"age"`
+	Number   *int   `json:"number"`
+	Position string `json:"position"`
+	Photo    string `json:"photo"`
+}
+
+// Squad is the current first-team squad of a club.
+type Squad struct {
+	Team    MTeam         `json:"team"`
+	Players []SquadPlayer `json:"players"`
+}
+
+// TopScorer is a row of a league's scoring chart.
+type TopScorer struct {
+	Rank   int    `json:"rank"`
+	Player Player `json:"player"`
+	PlayerSeasonStats
+}
+
+// SquadAPIResponse is the api-sports /players/squads response.
+type SquadAPIResponse struct {
+	Response []struct {
+		Team    MTeam         `json:"team"`
+		Players []SquadPlayer `json:"players"`
+	} `json:"response"`
+}
+
+// PlayersAPIResponse is the api-sports /players and /players/topscorers response.
+type PlayersAPIResponse struct {
+	Response []struct {
+		Player     Player `json:"player"`
+		Statistics []struct {
+			Team   MTeam `json:"team"`
+			League struct {
+				Name   string `json:"name"`
+				Season int    `json:"season"`
+			} `json:"league"`
+			Games struct {
+				Appearences *int    `json:"appearences"`
+				Minutes     *int    `json:"minutes"`
+				Position    string  `json:"position"`
+				Rating      *string `json:"rating"`
+			} `json:"games"`
+			Goals struct {
+				Total   *int `json:"total"`
+				Assists *int `json:"assists"`
+			} `json:"goals"`
+			Cards struct {
+				Yellow    *int `json:"yellow"`
+				YellowRed *int `json:"yellowred"`
+				Red       *int `json:"red"`
+			} `json:"cards"`
+		} `json:"statistics"`
+	} `json:"response"`
+}
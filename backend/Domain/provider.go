@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// FootballDataProvider is an upstream source of league data. Every adapter
+// maps its own payloads into the canonical PrevFixtures and
+// StandingsResponse types, so callers never see provider-specific shapes.
+// League is a registry code ("ETH") or api-sports id ("363").
+type FootballDataProvider interface {
+	Name() string
+	Fixtures(ctx context.Context, league string, season int, from, to string) ([]PrevFixtures, error)
+	Standings(ctx context.Context, league string, season int) (*StandingsResponse, error)
+}
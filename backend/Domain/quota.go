@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// QuotaBudget is the last known request allowance of an upstream provider.
+type QuotaBudget struct {
+	Provider        string `json:"provider"`
+	Limit           int    `json:"limit"`
+	Remaining       int    `json:"remaining"`
+	MinuteLimit     int    `json:"minute_limit,omitempty"`
+	MinuteRemaining int    `json:"minute_remaining,omitempty"`
+	Refused         int    `json:"refused"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+type IQuotaRepo interface {
+	GetBudget(ctx context.Context, provider string) (*QuotaBudget, error)
+	SaveBudget(ctx context.Context, budget *QuotaBudget) error
+	// CountRefused counts a call turned away for lack of budget. The count
+	// in QuotaBudget.Refused starts over each UTC day, with the budget.
+	CountRefused(ctx context.Context, provider string) error
+}
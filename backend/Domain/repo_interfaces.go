@@ -2,30 +2,73 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type IRedisRepo interface {
 	Get(ctx context.Context, teamId string) (*Team, error)
 	Add(ctx context.Context, team *Team) error
-	GetID(ctx context.Context, leagueID string) (int, error)
 	GetTeamByID(ctx context.Context, teamID int) (*Team, error)
 	SaveTeamByID(ctx context.Context, teamID int, team *Team) error
 	GetAllTeams(ctx context.Context, leagueID, season int) ([]Team, error)
 	SaveAllTeams(ctx context.Context, leagueID, season int, teams []Team) error
 	GetTeamStats(ctx context.Context, teamID int) (*TeamComparison, error)
 	SaveTeamStats(ctx context.Context, teamID int, stats *TeamComparison) error
-	CacheTeamID(ctx context.Context, teamName string, teamID string) error
+}
+
+// NewsQuery filters and pages the stored news feed, newest first.
+type NewsQuery struct {
+	Team   string    // canonical team name, empty for all teams
+	League string    // league code, empty for all leagues
+	Since  time.Time // zero for no lower bound
+	Offset int
+	Limit  int
+}
+
+type INewsRepo interface {
+	// SaveNews stores item under its ID. Re-saving a story keeps its
+	// original PublishedAt and sets UpdatedAt.
+	SaveNews(ctx context.Context, item NewsItem) error
+	GetNews(ctx context.Context, id string) (*NewsItem, error)
+	ListNews(ctx context.Context, q NewsQuery) ([]NewsItem, error)
 }
 
 type IStandingsRepo interface {
 	GetStandings(ctx context.Context, leagueID, season int) (*StandingsResponse, error)
 	SaveStandings(ctx context.Context, leagueID, season int, standings *StandingsResponse) error
 	GetStandingsFromCache(ctx context.Context, leagueID, season int) (*StandingsResponse, error)
+	// RefreshStandings fetches the table upstream now, however fresh the
+	// cached one is, and saves it with the snapshot of the day.
+	RefreshStandings(ctx context.Context, leagueID, season int) (*StandingsResponse, error)
+	// SaveSnapshot keeps the table of a day; a later save on the same day replaces it.
+	SaveSnapshot(ctx context.Context, leagueID, season int, snapshot StandingsSnapshot) error
+	// GetSnapshots returns the saved daily tables of a season, oldest first.
+	GetSnapshots(ctx context.Context, leagueID, season int) ([]StandingsSnapshot, error)
 }
 
 type IAPIService interface {
-	PrevFixtures(leagueID int, season int, fromDate, toDate string) (*[]PrevFixtures, error)
-	LiveFixtures(league string) (*[]PrevFixtures, error)
-	Statistics(league, season, team int) (*TeamComparison, error)
-	GetTeams(leagueID, season int) (*TeamsAPIResponse, error)
+	PrevFixtures(ctx context.Context, leagueID int, season int, fromDate, toDate string) (*[]PrevFixtures, error)
+	LiveFixtures(ctx context.Context, league string) (*[]PrevFixtures, error)
+	Statistics(ctx context.Context, league, season, team int) (*TeamComparison, error)
+	GetTeams(ctx context.Context, leagueID, season int) (*TeamsAPIResponse, error)
+	HeadToHead(ctx context.Context, teamA, teamB, last int) (*[]PrevFixtures, error)
+	FixtureDetail(ctx context.Context, fixtureID int) (*MatchDetail, error)
+	Squad(ctx context.Context, teamID int) (*Squad, error)
+	Player(ctx context.Context, playerID, season int) (*PlayerProfile, error)
+	TopScorers(ctx context.Context, leagueID, season int) ([]TopScorer, error)
+}
+
+type ISeasonRepo interface {
+	GetCurrentSeason(ctx context.Context, league string) (int, error)
+	SaveCurrentSeason(ctx context.Context, league string, season int, ttl time.Duration) error
+	GetSeasonWindow(ctx context.Context, league string, season int) (from string, to string, err error)
+	SaveSeasonWindow(ctx context.Context, league string, season int, from, to string) error
+}
+
+type IFollowRepo interface {
+	Follow(ctx context.Context, userID string, follow FollowedTeam) error
+	Unfollow(ctx context.Context, userID, teamID string) error
+	List(ctx context.Context, userID string) ([]FollowedTeam, error)
+	Get(ctx context.Context, userID, teamID string) (*FollowedTeam, error)
+	Followers(ctx context.Context, teamID string) ([]string, error)
 }
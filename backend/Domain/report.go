@@ -0,0 +1,23 @@
+package domain
+
+// ReportTemplates holds, per language code ("en", "am"), the text/template
+// source of every report phrase, keyed by phrase name ("win", "rout", ...).
+type ReportTemplates map[string]map[string]string
+
+// MatchFacts are what a match report is written from. Ranks are league
+// positions before and after the match, 0 when unknown.
+type MatchFacts struct {
+	Home           string
+	Away           string
+	HomeGoals      int
+	AwayGoals      int
+	HalftimeHome   *int
+	HalftimeAway   *int
+	Venue          string
+	Date           string
+	Derby          bool
+	HomeRankBefore int
+	HomeRankAfter  int
+	AwayRankBefore int
+	AwayRankAfter  int
+}
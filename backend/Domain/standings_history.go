@@ -0,0 +1,26 @@
+package domain
+
+// StandingsSnapshot is a league table as it stood on a given day.
+type StandingsSnapshot struct {
+	Date      string     `json:"date"` // YYYY-MM-DD, East Africa Time
+	Standings []Standing `json:"standings"`
+}
+
+// StandingsPoint is a team's place in the table on a given day.
+type StandingsPoint struct {
+	Date          string `json:"date"`
+	Rank          int    `json:"rank"`
+	Points        int    `json:"points"`
+	MatchesPlayed int    `json:"matchesPlayed"`
+	Change        int    `json:"change"` // places gained since the previous point, negative when dropping
+}
+
+// StandingsHistory is a team's rank and points over a season, oldest first.
+type StandingsHistory struct {
+	LeagueID int              `json:"leagueId"`
+	Season   int              `json:"season"`
+	TeamID   int              `json:"teamId,omitempty"`
+	TeamName string           `json:"teamName"`
+	Source   string           `json:"source"` // "snapshots" or "computed"
+	Points   []StandingsPoint `json:"points"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "context"
+
+// TeamAlias is a team under its canonical name with the other names fans
+// use for it, in English, Amharic and transliterated Amharic. ID is the
+// api-sports team id, zero until the team is seen upstream.
+type TeamAlias struct {
+	ID      int      `json:"id,omitempty"`
+	Name    string   `json:"name"`
+	League  string   `json:"league"`
+	Aliases []string `json:"aliases"`
+}
+
+// TeamMatch is one ranked result of resolving a team name.
+type TeamMatch struct {
+	ID      int     `json:"id,omitempty"`
+	Name    string  `json:"name"`
+	League  string  `json:"league"`
+	Matched string  `json:"matched"` // the alias that matched best
+	Score   float64 `json:"score"`   // 1 is an exact match
+}
+
+// ITeamDirectory lists the known teams of a league, or of every league
+// when league is empty.
+type ITeamDirectory interface {
+	Teams(league string) []TeamAlias
+}
+
+type ITeamAliasRepo interface {
+	SaveTeamAlias(ctx context.Context, team TeamAlias) error
+	AllTeamAliases(ctx context.Context) ([]TeamAlias, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+)
+
+// TelegramUserPrefix marks the user IDs of follows made through the
+// Telegram bot; the rest of the ID is the chat to notify.
+const TelegramUserPrefix = "tg:"
+
+// TelegramUserID is the follow user ID of a Telegram chat.
+func TelegramUserID(chatID int64) string {
+	return TelegramUserPrefix + strconv.FormatInt(chatID, 10)
+}
+
+// TelegramUpdate is the part of a Bot API update the bot reads.
+type TelegramUpdate struct {
+	UpdateID      int              `json:"update_id"`
+	Message       *TelegramMessage `json:"message,omitempty"`
+	EditedMessage *TelegramMessage `json:"edited_message,omitempty"`
+}
+
+type TelegramMessage struct {
+	MessageID int           `json:"message_id"`
+	From      *TelegramUser `json:"from,omitempty"`
+	Chat      TelegramChat  `json:"chat"`
+	Date      int64         `json:"date"`
+	Text      string        `json:"text"`
+}
+
+type TelegramUser struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	Username     string `json:"username,omitempty"`
+	LanguageCode string `json:"language_code,omitempty"`
+}
+
+type TelegramChat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+// IChatSender sends a text message to a chat.
+type IChatSender interface {
+	// SendMessage sends text as Markdown, falling back to plain text when
+	// the chat service cannot parse the markup.
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// IJobRepo holds the state the background jobs share between replicas.
+type IJobRepo interface {
+	// Claim takes the lease of a job run for ttl and reports whether it
+	// was free, so only one replica runs each scheduled run.
+	Claim(ctx context.Context, job string, ttl time.Duration) (bool, error)
+	// Release gives up the lease of a job run, so a failed run can be
+	// retried before the lease would expire.
+	Release(ctx context.Context, job string) error
+	// LastRun returns when a job last completed, zero if never.
+	LastRun(ctx context.Context, job string) (time.Time, error)
+	SetLastRun(ctx context.Context, job string, at time.Time) error
+	// SaveKickoffs replaces the known kickoff times of a league.
+	SaveKickoffs(ctx context.Context, league string, kickoffs []time.Time) error
+	// Kickoffs returns the saved kickoff times of a league: nil when none
+	// were saved, so the schedule is unknown, and empty when no match is
+	// coming up.
+	Kickoffs(ctx context.Context, league string) ([]time.Time, error)
+}
@@ -0,0 +1,133 @@
+// Package ethiotime converts between the Gregorian and Ethiopian calendars
+// and formats times the way Ethiopian fans read them: East Africa Time and
+// the local 12-hour clock that starts counting at 6 AM.
+package ethiotime
+
+import (
+	"fmt"
+	"time"
+)
+
+// EAT is East Africa Time (UTC+3, no daylight saving).
+var EAT = time.FixedZone("EAT", 3*60*60)
+
+// Julian day number of Meskerem 1, year 1 (Amete Mihret era) minus one.
+const ethiopianEpoch = 1723856
+
+// unixEpochJDN is the Julian day number of 1970-01-01.
+const unixEpochJDN = 2440588
+
+var monthNames = map[string][13]string{
+	"en": {"Meskerem", "Tikimt", "Hidar", "Tahsas", "Tir", "Yekatit", "Megabit", "Miyazya", "Ginbot", "Sene", "Hamle", "Nehase", "Pagume"},
+	"am": {"መስከረም", "ጥቅምት", "ኅዳር", "ታኅሣሥ", "ጥር", "የካቲት", "መጋቢት", "ሚያዝያ", "ግንቦት", "ሰኔ", "ሐምሌ", "ነሐሴ", "ጳጉሜ"},
+}
+
+// Date is a day in the Ethiopian calendar. Month 13 is Pagume.
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// FromGregorian returns the Ethiopian date of the calendar day of t in t's location.
+func FromGregorian(t time.Time) Date {
+	y, m, d := t.Date()
+	days := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	jdn := int(days) + unixEpochJDN
+
+	r := mod(jdn-ethiopianEpoch, 1461)
+	n := r%365 + 365*(r/1460)
+
+	return Date{
+		Year:  4*floorDiv(jdn-ethiopianEpoch, 1461) + r/365 - r/1460,
+		Month: n/30 + 1,
+		Day:   n%30 + 1,
+	}
+}
+
+// ToGregorian returns midnight of the Gregorian day matching d in loc.
+func (d Date) ToGregorian(loc *time.Location) time.Time {
+	jdn := ethiopianEpoch + 365 + 365*(d.Year-1) + floorDiv(d.Year, 4) + 30*d.Month + d.Day - 31
+	t := time.Unix(int64(jdn-unixEpochJDN)*86400, 0).UTC()
+
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// MonthName returns the month name in "en" (transliterated) or "am" (Ge'ez script).
+func (d Date) MonthName(lang string) string {
+	names, ok := monthNames[lang]
+	if !ok {
+		names = monthNames["en"]
+	}
+	if d.Month < 1 || d.Month > 13 {
+		return ""
+	}
+	return names[d.Month-1]
+}
+
+// Format renders the date, e.g. "Meskerem 1, 2016" or "መስከረም 1 ቀን 2016".
+func (d Date) Format(lang string) string {
+	if lang == "am" {
+		return fmt.Sprintf("%s %d ቀን %d", d.MonthName(lang), d.Day, d.Year)
+	}
+	return fmt.Sprintf("%s %d, %d", d.MonthName(lang), d.Day, d.Year)
+}
+
+// LocalClock converts t to the Ethiopian 12-hour clock in t's location.
+// The clock reads 12 at 6 AM and 6 PM, so 15:00 is 9 o'clock in the afternoon.
+func LocalClock(t time.Time) (hour, minute int, period string) {
+	h := t.Hour()
+
+	hour = (h + 6) % 12
+	if hour == 0 {
+		hour = 12
+	}
+
+	switch {
+	case h >= 6 && h < 12:
+		period = "morning"
+	case h >= 12 && h < 18:
+		period = "afternoon"
+	case h >= 18:
+		period = "evening"
+	default:
+		period = "night"
+	}
+
+	return hour, t.Minute(), period
+}
+
+var periodNames = map[string]string{
+	"morning":   "ጠዋት",
+	"afternoon": "ከሰዓት",
+	"evening":   "ምሽት",
+	"night":     "ሌሊት",
+}
+
+// FormatClock renders the Ethiopian local time, e.g. "9:00 in the afternoon" or "ከሰዓት 9:00".
+func FormatClock(t time.Time, lang string) string {
+	hour, minute, period := LocalClock(t)
+	if lang == "am" {
+		return fmt.Sprintf("%s %d:%02d", periodNames[period], hour, minute)
+	}
+	return fmt.Sprintf("%d:%02d in the %s", hour, minute, period)
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func mod(a, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
@@ -0,0 +1,78 @@
+package ethiotime_test
+
+import (
+	"testing"
+	"time"
+
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+func TestFromGregorian(t *testing.T) {
+	for _, tc := range []struct {
+		gregorian string
+		want      ethiotime.Date
+	}{
+		{"2023-09-11", ethiotime.Date{Year: 2015, Month: 13, Day: 6}}, // Pagume 6 of a leap year
+		{"2023-09-12", ethiotime.Date{Year: 2016, Month: 1, Day: 1}},
+		{"2024-01-07", ethiotime.Date{Year: 2016, Month: 4, Day: 28}}, // Genna
+		{"2024-09-10", ethiotime.Date{Year: 2016, Month: 13, Day: 5}},
+		{"2024-09-11", ethiotime.Date{Year: 2017, Month: 1, Day: 1}},
+		{"2027-09-11", ethiotime.Date{Year: 2019, Month: 13, Day: 6}},
+		{"2027-09-12", ethiotime.Date{Year: 2020, Month: 1, Day: 1}},
+	} {
+		day, err := time.Parse("2006-01-02", tc.gregorian)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ethiotime.FromGregorian(day)
+		if got != tc.want {
+			t.Errorf("FromGregorian(%s) = %+v, want %+v", tc.gregorian, got, tc.want)
+		}
+		if back := got.ToGregorian(time.UTC); !back.Equal(day) {
+			t.Errorf("%+v.ToGregorian() = %s, want %s", got, back.Format("2006-01-02"), tc.gregorian)
+		}
+	}
+}
+
+func TestFromGregorianUsesTheLocalDay(t *testing.T) {
+	// 22:30 UTC on Pagume 5 is already Meskerem 1 in Addis Ababa
+	late := time.Date(2024, 9, 10, 22, 30, 0, 0, time.UTC)
+
+	if got := ethiotime.FromGregorian(late.In(ethiotime.EAT)); got != (ethiotime.Date{Year: 2017, Month: 1, Day: 1}) {
+		t.Errorf("FromGregorian in EAT = %+v, want Meskerem 1, 2017", got)
+	}
+}
+
+func TestDateFormat(t *testing.T) {
+	d := ethiotime.Date{Year: 2016, Month: 1, Day: 1}
+
+	for lang, want := range map[string]string{"en": "Meskerem 1, 2016", "am": "መስከረም 1 ቀን 2016", "fr": "Meskerem 1, 2016"} {
+		if got := d.Format(lang); got != want {
+			t.Errorf("Format(%q) = %q, want %q", lang, got, want)
+		}
+	}
+	if got := (ethiotime.Date{Year: 2016, Month: 14, Day: 1}).MonthName("en"); got != "" {
+		t.Errorf("month 14 = %q, want no name", got)
+	}
+}
+
+func TestLocalClock(t *testing.T) {
+	for _, tc := range []struct {
+		hour, minute int
+		en, am       string
+	}{
+		{6, 0, "12:00 in the morning", "ጠዋት 12:00"},
+		{9, 30, "3:30 in the morning", "ጠዋት 3:30"},
+		{15, 0, "9:00 in the afternoon", "ከሰዓት 9:00"},
+		{18, 0, "12:00 in the evening", "ምሽት 12:00"},
+		{0, 5, "6:05 in the night", "ሌሊት 6:05"},
+	} {
+		at := time.Date(2024, 3, 2, tc.hour, tc.minute, 0, 0, ethiotime.EAT)
+		if got := ethiotime.FormatClock(at, "en"); got != tc.en {
+			t.Errorf("FormatClock(%02d:%02d, en) = %q, want %q", tc.hour, tc.minute, got, tc.en)
+		}
+		if got := ethiotime.FormatClock(at, "am"); got != tc.am {
+			t.Errorf("FormatClock(%02d:%02d, am) = %q, want %q", tc.hour, tc.minute, got, tc.am)
+		}
+	}
+}
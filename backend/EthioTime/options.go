@@ -0,0 +1,102 @@
+package ethiotime
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // tz= must work in slim containers without a zoneinfo database
+)
+
+const (
+	Gregorian = "gregorian"
+	Ethiopian = "ethiopian"
+)
+
+// Options describe how a client wants dates rendered. The zero value keeps
+// the original output: Gregorian dates in UTC with English labels.
+type Options struct {
+	Calendar string
+	Location *time.Location
+	Language string
+}
+
+// ParseOptions validates the calendar=, tz= and lang= query values.
+// The Ethiopian calendar defaults to East Africa Time when tz is empty.
+func ParseOptions(calendar, tz, lang string) (Options, error) {
+	opts := Options{Language: "en"}
+
+	switch strings.ToLower(strings.TrimSpace(calendar)) {
+	case "", Gregorian:
+		opts.Calendar = Gregorian
+	case Ethiopian:
+		opts.Calendar = Ethiopian
+	default:
+		return Options{}, fmt.Errorf("unsupported calendar %q (use gregorian or ethiopian)", calendar)
+	}
+
+	switch tz = strings.TrimSpace(tz); {
+	case tz == "" && opts.Calendar == Ethiopian, strings.EqualFold(tz, "EAT"):
+		opts.Location = EAT
+	case tz == "":
+		opts.Location = time.UTC
+	default:
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return Options{}, fmt.Errorf("unknown time zone %q", tz)
+		}
+		opts.Location = loc
+	}
+
+	switch strings.ToLower(strings.TrimSpace(lang)) {
+	case "am", "amharic":
+		opts.Language = "am"
+	}
+
+	return opts, nil
+}
+
+// IsSet reports whether the client asked for anything beyond the defaults.
+func (o Options) IsSet() bool {
+	return o.Calendar == Ethiopian || (o.Location != nil && o.Location != time.UTC)
+}
+
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.UTC
+	}
+	return o.Location
+}
+
+// FormatDate renders the calendar day of t.
+func (o Options) FormatDate(t time.Time) string {
+	t = t.In(o.location())
+	if o.Calendar == Ethiopian {
+		return FromGregorian(t).Format(o.Language)
+	}
+	return t.Format("02 Jan 2006")
+}
+
+// FormatDateTime renders the day and kickoff time of t.
+func (o Options) FormatDateTime(t time.Time) string {
+	t = t.In(o.location())
+	if o.Calendar == Ethiopian {
+		return FromGregorian(t).Format(o.Language) + ", " + FormatClock(t, o.Language)
+	}
+	return t.Format("02 Jan 2006 15:04 MST")
+}
+
+// FormatTimestamp parses an upstream timestamp and renders it with
+// FormatDateTime, or FormatDate when it carries no time of day. Unparseable
+// input is returned unchanged.
+func (o Options) FormatTimestamp(s string) string {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return o.FormatDateTime(t)
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return o.FormatDateTime(t)
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, o.location()); err == nil {
+		return o.FormatDate(t)
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package ethiotime_test
+
+import (
+	"testing"
+	"time"
+
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+func TestParseOptions(t *testing.T) {
+	for _, tc := range []struct {
+		calendar, tz, lang string
+		want               ethiotime.Options
+	}{
+		{"", "", "", ethiotime.Options{Calendar: ethiotime.Gregorian, Location: time.UTC, Language: "en"}},
+		{"ethiopian", "", "", ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: ethiotime.EAT, Language: "en"}},
+		{"Ethiopian", "UTC", "amharic", ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: time.UTC, Language: "am"}},
+		{"gregorian", "eat", "am", ethiotime.Options{Calendar: ethiotime.Gregorian, Location: ethiotime.EAT, Language: "am"}},
+	} {
+		got, err := ethiotime.ParseOptions(tc.calendar, tc.tz, tc.lang)
+		if err != nil {
+			t.Fatalf("ParseOptions(%q, %q, %q): %v", tc.calendar, tc.tz, tc.lang, err)
+		}
+		if got.Calendar != tc.want.Calendar || got.Location.String() != tc.want.Location.String() || got.Language != tc.want.Language {
+			t.Errorf("ParseOptions(%q, %q, %q) = %+v, want %+v", tc.calendar, tc.tz, tc.lang, got, tc.want)
+		}
+	}
+
+	for _, bad := range [][2]string{{"julian", ""}, {"", "Mars/Olympus"}} {
+		if _, err := ethiotime.ParseOptions(bad[0], bad[1], ""); err == nil {
+			t.Errorf("ParseOptions(%q, %q) accepted", bad[0], bad[1])
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	addis, err := time.LoadLocation("Africa/Addis_Ababa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		opts ethiotime.Options
+		in   string
+		want string
+	}{
+		{ethiotime.Options{}, "2023-09-11T21:30:00+00:00", "11 Sep 2023 21:30 UTC"},
+		{ethiotime.Options{Location: ethiotime.EAT}, "2023-09-11T21:30:00+00:00", "12 Sep 2023 00:30 EAT"},
+		{ethiotime.Options{Location: addis}, "2023-09-11T21:30:00+00:00", "12 Sep 2023 00:30 EAT"},
+		{ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: ethiotime.EAT, Language: "en"}, "2023-09-12T12:00:00Z", "Meskerem 1, 2016, 9:00 in the afternoon"},
+		{ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: ethiotime.EAT, Language: "am"}, "2023-09-12 12:00:00", "መስከረም 1 ቀን 2016, ከሰዓት 9:00"},
+		{ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: ethiotime.EAT, Language: "en"}, "2024-09-10", "Pagume 5, 2016"},
+		{ethiotime.Options{}, "not a date", "not a date"},
+	} {
+		if got := tc.opts.FormatTimestamp(tc.in); got != tc.want {
+			t.Errorf("%+v.FormatTimestamp(%q) = %q, want %q", tc.opts, tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestOptionsIsSet(t *testing.T) {
+	for opts, want := range map[ethiotime.Options]bool{
+		{}:                              false,
+		{Location: time.UTC}:            false,
+		{Location: ethiotime.EAT}:       true,
+		{Calendar: ethiotime.Ethiopian}: true,
+	} {
+		if got := opts.IsSet(); got != want {
+			t.Errorf("%+v.IsSet() = %v, want %v", opts, got, want)
+		}
+	}
+}
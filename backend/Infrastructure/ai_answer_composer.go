@@ -2,7 +2,6 @@ package infrastructure
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
@@ -33,28 +32,7 @@ func (c *AIAnswerComposer) composeMarkdown(dCtx domain.AnswerContext) (*domain.A
 	defer client.Close()
 
 	model := client.GenerativeModel("gemini-1.5-flash-latest")
-	contextBytes, _ := json.MarshalIndent(dCtx.ContextData, "", "  ")
-
-	// language := "English"
-	// if dCtx.Language == "am" {
-	// 	language = "Amharic"
-	// }
-
-	prompt := fmt.Sprintf(`You are a helpful and concise football assistant for Ethiopian fans.
-	Your task is to write a short, friendly summary in %s using ONLY the data provided below.
-
-	**Rules:**
-	- Use ONLY the provided data. Do not make up scores, fixtures, or facts.
-	- If a piece of information is missing from the data, say "it is not available" or "is not confirmed."
-	- The output MUST be markdown.
-	- The tone should be friendly and respectful of all clubs.
-	- NO betting or gambling language.
-	- For Compare outline that this is a season 2022 stat comparisions
-
-	**Provided Data (JSON format):**
-	%s
-
-	Now, write the summary:`, dCtx.Language, string(contextBytes))
+	prompt := answerPrompt(dCtx)
 
 	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
@@ -11,11 +11,13 @@ import (
 )
 
 type AIIntentParser struct {
-	apiKey string
+	apiKey  string
+	teams   domain.ITeamDirectory
+	leagues domain.ILeagueRegistry
 }
 
-func NewAIIntentParser(apiKey string) *AIIntentParser {
-	return &AIIntentParser{apiKey}
+func NewAIIntentParser(apiKey string, teams domain.ITeamDirectory, leagues domain.ILeagueRegistry) *AIIntentParser {
+	return &AIIntentParser{apiKey: apiKey, teams: teams, leagues: leagues}
 }
 
 func (ip AIIntentParser) Parse(text string) (*domain.Intent, error) {
@@ -33,7 +35,7 @@ func (ip AIIntentParser) Parse(text string) (*domain.Intent, error) {
 			Properties: map[string]*genai.Schema{
 				"topic": {
 					Type: genai.TypeString,
-					Enum: []string{"fixture", "table", "compare", "news", "fact"},
+					Enum: intentTopics,
 				},
 				"teams": {
 					Type: genai.TypeArray,
@@ -41,7 +43,10 @@ func (ip AIIntentParser) Parse(text string) (*domain.Intent, error) {
 						Type: genai.TypeString,
 					},
 				},
-				"league":    {Type: genai.TypeString},
+				"league": {
+					Type: genai.TypeString,
+					Enum: leagueCodes(ip.leagues),
+				},
 				"date":      {Type: genai.TypeString},
 				"follow_up": {Type: genai.TypeString},
 				"language" : {Type: genai.TypeString},
@@ -54,74 +59,7 @@ func (ip AIIntentParser) Parse(text string) (*domain.Intent, error) {
 		ctx,
 		"gemini-2.5-flash",
 		genai.Text(
-			`System Prompt: Detect League Context: When a user asks about a match, standings, results, 
-			live scores, or any league-related query, identify that the request is 
-			about football.Default assumption: unless a specific league is mentioned, provide 
-			information for Ethiopian Premier League (ETH) and English Premier League (EPL) in 
-			the specified order. Response Order: Step 1: Provide data for the Ethiopian Premier 
-			League (ETH). Step 2: Provide data for the English Premier League (EPL). Returns only 
-			shorts for premier league 'ETH' for Ethiopian 'EPL' for English Keep the 
-			order consistent: ETH first, EPL second. Language Handling: If the user writes in 
-			Amharic or explicitly wants to interact in Amharic, all responses, including headings 
-			and match details, should be in Amharic. Include a field in the response 'language': 
-			'amharic'. If the user writes in English or wants English responses, respond in English 
-			and set language": "english. Auto-detect language preference from the user prompt and if 
-			the langaue is amaharic change translate the language into english for the intent but the 
-			language field should not be changed since will need it for answer. the name of the team 
-			you insert to intent should be in one of the following.  
-
-			teams for ethiopian premier league: Here's the list of team names:[ 
-						"Adama Kenema",
-						"Awassa Kenema",
-						"Bahardar",
-						"Dire Dawa Kenema",
-						"Ethiopia Bunna",
-						"Fasil Ketema",
-						"Kedus Giorgis",
-						"Mekelakeya",
-						"Sidama Bunna",
-						"Welayta Dicha",
-						"Arba Minch Kenema",
-						"Addis Ababa Ketema",
-						"Hadiya Hosaena",
-						"Jimma Aba Jifar",
-						"Sebeta Kenema",
-						"Wolkite Ketema",
-						"Mebrat Hayl",
-						"Ethiopian Medhin",
-						"Legetafo Legedadi",
-						"Ethiopia Nigd Bank",
-						"Hambericho Durame",
-						"Shashemene Kenema"
-					]
-
-		teams for english premierleague: [
-							"Manchester United",
-							"Newcastle",
-							"Bournemouth",
-							"Fulham",
-							"Wolves",
-							"Liverpool",
-							"Southampton",
-							"Arsenal",
-							"Everton",
-							"Leicester",
-							"Tottenham",
-							"West Ham",
-							"Chelsea",
-							"Manchester City",
-							"Brighton",
-							"Crystal Palace",
-							"Brentford",
-							"Leeds",
-							"Nottingham Forest",
-							"Aston Villa",
-							"Watford",
-							"Burnley",
-							"Norwich",
-							"Sheffield Utd",
-							"Luton"
-					]` + "\n\nuser prompt" + text,
+			intentPrompt(text, leagueListPrompt(ip.leagues), teamListPrompt(ip.teams, ip.leagues)),
 		),
 		config,
 	)
@@ -1,53 +1,45 @@
 package infrastructure
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
+	"net/url"
+	"strconv"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 )
 
-func NewAPIService() domain.IAPIService {
-	return &APIServiceClient{}
+func NewAPIService(leagues domain.ILeagueRegistry, client *APISportsClient) domain.IAPIService {
+	return &APIServiceClient{leagues: leagues, client: client}
 }
 
-type APIServiceClient struct{}
-
-func (hs APIServiceClient) PrevFixtures(leagueID int, season int, fromDate, toDate string) (*[]domain.PrevFixtures, error) {
-
-	API_KEY := os.Getenv("API_SPORTS_API_KEY")
-
-	url := fmt.Sprintf(
-		"https://v3.football.api-sports.io/fixtures?league=%d&season=%d&from=%s&to=%s",
-		leagueID, season, fromDate, toDate,
-	)
-
-	client := &http.Client{}
+type APIServiceClient struct {
+	leagues domain.ILeagueRegistry
+	client  *APISportsClient
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return nil, domain.ErrInternalServer
+// upstreamErr keeps quota refusals visible to callers and hides other failures.
+func upstreamErr(err error) error {
+	if errors.Is(err, domain.ErrQuotaExhausted) {
+		return err
 	}
+	return domain.ErrInternalServer
+}
 
-	// API headers
-	req.Header.Set("x-rapidapi-key", API_KEY)
-	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
+func (hs APIServiceClient) PrevFixtures(ctx context.Context, leagueID int, season int, fromDate, toDate string) (*[]domain.PrevFixtures, error) {
 
-	res, err := client.Do(req)
-	if err != nil {
-		fmt.Println("Error making request:", err)
-		return nil, domain.ErrInternalServer
-	}
-	defer res.Body.Close()
+	params := url.Values{}
+	params.Set("league", strconv.Itoa(leagueID))
+	params.Set("season", strconv.Itoa(season))
+	params.Set("from", fromDate)
+	params.Set("to", toDate)
 
-	body, err := io.ReadAll(res.Body)
+	body, err := hs.client.Get(ctx, "/fixtures", params, PriorityNormal)
 	if err != nil {
-		fmt.Println("Error reading response:", err)
-		return nil, domain.ErrInternalServer
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
 	}
 
 	var apiResponse domain.APIResponse
@@ -58,16 +50,19 @@ func (hs APIServiceClient) PrevFixtures(leagueID int, season int, fromDate, toDa
 	var prevFixtures = &[]domain.PrevFixtures{}
 	for _, r := range apiResponse.Response {
 		fixture := domain.PrevFixtures{
+			FixtureID:   r.Fixture.ID,
 			Date:        r.Fixture.Date,
 			Venue:       r.Fixture.Venue.Name,
 			League:      r.League.Name,
 			LeagueRound: r.League.Round,
 			HomeTeam: domain.MTeam{
+				ID:   r.Teams.Home.ID,
 				Name: r.Teams.Home.Name,
 				Logo: r.Teams.Home.Logo,
 			},
 
 			AwayTeam: domain.MTeam{
+				ID:   r.Teams.Away.ID,
 				Name: r.Teams.Away.Name,
 				Logo: r.Teams.Away.Logo,
 			},
@@ -91,42 +86,21 @@ func (hs APIServiceClient) PrevFixtures(leagueID int, season int, fromDate, toDa
 	return prevFixtures, nil
 }
 
-func (ac *APIServiceClient) LiveFixtures(league string) (*[]domain.PrevFixtures, error) {
-
-	ids := ""
-	if league == "ETH" {
-		ids = "363"
-	} else {
-		ids = "39"
-	}
-
-	API_KEY := os.Getenv("API_SPORTS_API_KEY")
-
-	url := fmt.Sprintf("https://v3.football.api-sports.io/fixtures?live=%s", ids)
+func (ac *APIServiceClient) LiveFixtures(ctx context.Context, league string) (*[]domain.PrevFixtures, error) {
 
-	client := &http.Client{}
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	l, err := ac.leagues.Resolve(league)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return nil, domain.ErrInternalServer
+		return nil, err
 	}
+	ids := strconv.Itoa(l.APISportsID)
 
-	// API headers
-	req.Header.Set("x-rapidapi-key", API_KEY)
-	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
+	params := url.Values{}
+	params.Set("live", ids)
 
-	res, err := client.Do(req)
+	body, err := ac.client.Get(ctx, "/fixtures", params, PriorityCritical)
 	if err != nil {
 		fmt.Println("Error making request:", err)
-		return nil, domain.ErrInternalServer
-	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println("Error reading response:", err)
-		return nil, domain.ErrInternalServer
+		return nil, upstreamErr(err)
 	}
 
 	var apiResponse domain.APIResponse
@@ -143,16 +117,19 @@ func (ac *APIServiceClient) LiveFixtures(league string) (*[]domain.PrevFixtures,
 	for _, r := range apiResponse.Response {
 
 		fixture := domain.PrevFixtures{
+			FixtureID:   r.Fixture.ID,
 			Date:        r.Fixture.Date,
 			Venue:       r.Fixture.Venue.Name,
 			League:      r.League.Name,
 			LeagueRound: r.League.Round,
 			HomeTeam: domain.MTeam{
+				ID:   r.Teams.Home.ID,
 				Name: r.Teams.Home.Name,
 				Logo: r.Teams.Home.Logo,
 			},
 
 			AwayTeam: domain.MTeam{
+				ID:   r.Teams.Away.ID,
 				Name: r.Teams.Away.Name,
 				Logo: r.Teams.Away.Logo,
 			},
@@ -179,35 +156,17 @@ func (ac *APIServiceClient) LiveFixtures(league string) (*[]domain.PrevFixtures,
 
 }
 
-func (ac *APIServiceClient) Statistics(league, season, team int) (*domain.TeamComparison, error) {
-
-	API_KEY := os.Getenv("API_SPORTS_API_KEY")
-
-	url := fmt.Sprintf("https://v3.football.api-sports.io/teams/statistics?league=%d&season=%d&team=%d", league, season, team)
+func (ac *APIServiceClient) Statistics(ctx context.Context, league, season, team int) (*domain.TeamComparison, error) {
 
-	client := &http.Client{}
+	params := url.Values{}
+	params.Set("league", strconv.Itoa(league))
+	params.Set("season", strconv.Itoa(season))
+	params.Set("team", strconv.Itoa(team))
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		fmt.Println("Error creating request:", err)
-		return nil, domain.ErrInternalServer
-	}
-
-	// API headers
-	req.Header.Set("x-rapidapi-key", API_KEY)
-	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
-
-	res, err := client.Do(req)
+	body, err := ac.client.Get(ctx, "/teams/statistics", params, PriorityNormal)
 	if err != nil {
 		fmt.Println("Error making request:", err)
-		return nil, domain.ErrInternalServer
-	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		fmt.Println("Error reading response:", err)
-		return nil, domain.ErrInternalServer
+		return nil, upstreamErr(err)
 	} 
 	fmt.Println("body : ", string(body))
 
@@ -235,41 +194,258 @@ func (ac *APIServiceClient) Statistics(league, season, team int) (*domain.TeamCo
 	return teamData, nil
 }
 
-func (ac *APIServiceClient) GetTeams(leagueID, season int) (*domain.TeamsAPIResponse, error) {
-	API_KEY := os.Getenv("API_SPORTS_API_KEY")
+func (ac *APIServiceClient) GetTeams(ctx context.Context, leagueID, season int) (*domain.TeamsAPIResponse, error) {
+	params := url.Values{}
+	params.Set("league", strconv.Itoa(leagueID))
+	params.Set("season", strconv.Itoa(season))
 
-	url := fmt.Sprintf("https://v3.football.api-sports.io/teams?league=%d&season=%d", leagueID, season)
+	body, err := ac.client.Get(ctx, "/teams", params, PriorityLow)
+	if err != nil {
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
 
-	client := &http.Client{}
+	var apiResponse domain.TeamsAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, domain.ErrInternalServer
+	}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	
+	return &apiResponse, nil
+}
+
+// HeadToHead returns the last meetings of two teams in any competition,
+// upcoming ones included; last <= 0 returns them all.
+func (ac *APIServiceClient) HeadToHead(ctx context.Context, teamA, teamB, last int) (*[]domain.PrevFixtures, error) {
+	params := url.Values{}
+	params.Set("h2h", fmt.Sprintf("%d-%d", teamA, teamB))
+	if last > 0 {
+		params.Set("last", strconv.Itoa(last))
+	}
+
+	body, err := ac.client.Get(ctx, "/fixtures/headtohead", params, PriorityNormal)
 	if err != nil {
-		fmt.Println("Error creating request:", err)
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.APIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, domain.ErrInternalServer
 	}
 
-	// API headers
-	req.Header.Set("x-rapidapi-key", API_KEY)
-	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
+	meetings := []domain.PrevFixtures{}
+	for _, r := range apiResponse.Response {
+		meetings = append(meetings, domain.PrevFixtures{
+			FixtureID:   r.Fixture.ID,
+			Date:        r.Fixture.Date,
+			Venue:       r.Fixture.Venue.Name,
+			League:      r.League.Name,
+			LeagueRound: r.League.Round,
+			HomeTeam:    domain.MTeam{ID: r.Teams.Home.ID, Name: r.Teams.Home.Name, Logo: r.Teams.Home.Logo},
+			AwayTeam:    domain.MTeam{ID: r.Teams.Away.ID, Name: r.Teams.Away.Name, Logo: r.Teams.Away.Logo},
+			Goals:       domain.Goals{Home: r.Goals.Home, Away: r.Goals.Away},
+			Score: domain.Score{
+				Halftime:  domain.Goals(r.Score.Halftime),
+				Fulltime:  domain.Goals(r.Score.Fulltime),
+				Extratime: domain.Goals(r.Score.Extratime),
+				Penalty:   domain.Goals(r.Score.Penalty),
+			},
+			Status: r.Fixture.Status,
+		})
+	}
+	return &meetings, nil
+}
 
-	res, err := client.Do(req)
+// FixtureDetail returns a fixture with its events, lineups and statistics,
+// which api-sports includes when a single fixture is requested by id.
+func (ac *APIServiceClient) FixtureDetail(ctx context.Context, fixtureID int) (*domain.MatchDetail, error) {
+	params := url.Values{}
+	params.Set("id", strconv.Itoa(fixtureID))
+
+	body, err := ac.client.Get(ctx, "/fixtures", params, PriorityNormal)
+	if err != nil {
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.FixtureDetailAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	if len(apiResponse.Response) == 0 {
+		return nil, domain.ErrFixtureNotFound
+	}
+	r := apiResponse.Response[0]
+
+	detail := &domain.MatchDetail{
+		PrevFixtures: domain.PrevFixtures{
+			FixtureID:   r.Fixture.ID,
+			Date:        r.Fixture.Date,
+			Venue:       r.Fixture.Venue.Name,
+			League:      r.League.Name,
+			LeagueRound: r.League.Round,
+			HomeTeam:    domain.MTeam{ID: r.Teams.Home.ID, Name: r.Teams.Home.Name, Logo: r.Teams.Home.Logo},
+			AwayTeam:    domain.MTeam{ID: r.Teams.Away.ID, Name: r.Teams.Away.Name, Logo: r.Teams.Away.Logo},
+			Goals:       domain.Goals{Home: r.Goals.Home, Away: r.Goals.Away},
+			Score: domain.Score{
+				Halftime:  domain.Goals(r.Score.Halftime),
+				Fulltime:  domain.Goals(r.Score.Fulltime),
+				Extratime: domain.Goals(r.Score.Extratime),
+				Penalty:   domain.Goals(r.Score.Penalty),
+			},
+			Status: r.Fixture.Status,
+		},
+		Events:     []domain.MatchEvent{},
+		Lineups:    []domain.Lineup{},
+		Statistics: r.Statistics,
+	}
+	if detail.Statistics == nil {
+		detail.Statistics = []domain.TeamMatchStats{}
+	}
+
+	for _, e := range r.Events {
+		detail.Events = append(detail.Events, domain.MatchEvent{
+			Elapsed:  e.Time.Elapsed,
+			Extra:    e.Time.Extra,
+			Team:     e.Team,
+			Player:   e.Player,
+			Assist:   e.Assist,
+			Type:     e.Type,
+			Detail:   e.Detail,
+			Comments: e.Comments,
+		})
+	}
+
+	for _, l := range r.Lineups {
+		lineup := domain.Lineup{
+			Team:        l.Team,
+			Coach:       l.Coach.Name,
+			Formation:   l.Formation,
+			StartXI:     []domain.LineupPlayer{},
+			Substitutes: []domain.LineupPlayer{},
+		}
+		for _, p := range l.StartXI {
+			lineup.StartXI = append(lineup.StartXI, p.Player)
+		}
+		for _, p := range l.Substitutes {
+			lineup.Substitutes = append(lineup.Substitutes, p.Player)
+		}
+		detail.Lineups = append(detail.Lineups, lineup)
+	}
+
+	return detail, nil
+}
+
+// Squad returns the current squad of a team.
+func (ac *APIServiceClient) Squad(ctx context.Context, teamID int) (*domain.Squad, error) {
+	params := url.Values{}
+	params.Set("team", strconv.Itoa(teamID))
+
+	body, err := ac.client.Get(ctx, "/players/squads", params, PriorityLow)
 	if err != nil {
 		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.SquadAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, domain.ErrInternalServer
 	}
-	defer res.Body.Close()
+	if len(apiResponse.Response) == 0 {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	r := apiResponse.Response[0]
+	squad := &domain.Squad{Team: r.Team, Players: r.Players}
+	if squad.Players == nil {
+		squad.Players = []domain.SquadPlayer{}
+	}
+	return squad, nil
+}
 
-	body, err := io.ReadAll(res.Body)
+// Player returns a player's profile with statistics for season.
+func (ac *APIServiceClient) Player(ctx context.Context, playerID, season int) (*domain.PlayerProfile, error) {
+	params := url.Values{}
+	params.Set("id", strconv.Itoa(playerID))
+	params.Set("season", strconv.Itoa(season))
+
+	body, err := ac.client.Get(ctx, "/players", params, PriorityLow)
 	if err != nil {
-		fmt.Println("Error reading response:", err)
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.PlayersAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, domain.ErrInternalServer
 	}
+	profiles := playerProfiles(apiResponse)
+	if len(profiles) == 0 {
+		return nil, domain.ErrPlayerNotFound
+	}
+	return &profiles[0], nil
+}
 
-	var apiResponse domain.TeamsAPIResponse
+// TopScorers returns the scoring chart of a league, best first.
+func (ac *APIServiceClient) TopScorers(ctx context.Context, leagueID, season int) ([]domain.TopScorer, error) {
+	params := url.Values{}
+	params.Set("league", strconv.Itoa(leagueID))
+	params.Set("season", strconv.Itoa(season))
+
+	body, err := ac.client.Get(ctx, "/players/topscorers", params, PriorityNormal)
+	if err != nil {
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.PlayersAPIResponse
 	if err := json.Unmarshal(body, &apiResponse); err != nil {
 		return nil, domain.ErrInternalServer
 	}
 
-	
-	return &apiResponse, nil
+	scorers := []domain.TopScorer{}
+	for i, p := range playerProfiles(apiResponse) {
+		scorer := domain.TopScorer{Rank: i + 1, Player: p.Player}
+		if len(p.Statistics) > 0 {
+			scorer.PlayerSeasonStats = p.Statistics[0]
+		}
+		scorers = append(scorers, scorer)
+	}
+	return scorers, nil
+}
+
+// playerProfiles flattens the nullable api-sports player statistics.
+func playerProfiles(apiResponse domain.PlayersAPIResponse) []domain.PlayerProfile {
+	count := func(n *int) int {
+		if n == nil {
+			return 0
+		}
+		return *n
+	}
+
+	profiles := []domain.PlayerProfile{}
+	for _, r := range apiResponse.Response {
+		profile := domain.PlayerProfile{Player: r.Player, Statistics: []domain.PlayerSeasonStats{}}
+		for _, s := range r.Statistics {
+			stats := domain.PlayerSeasonStats{
+				Team:        s.Team,
+				League:      s.League.Name,
+				Season:      s.League.Season,
+				Position:    s.Games.Position,
+				Appearances: count(s.Games.Appearences),
+				Minutes:     count(s.Games.Minutes),
+				Goals:       count(s.Goals.Total),
+				Assists:     count(s.Goals.Assists),
+				YellowCards: count(s.Cards.Yellow),
+				RedCards:    count(s.Cards.Red) + count(s.Cards.YellowRed),
+			}
+			if s.Games.Rating != nil {
+				stats.Rating = *s.Games.Rating
+			}
+			profile.Statistics = append(profile.Statistics, stats)
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles
 }
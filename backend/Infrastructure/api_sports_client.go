@@ -0,0 +1,181 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const apiSportsProvider = "api-sports"
+
+// Priority decides which calls may still spend quota when the budget runs low.
+type Priority int
+
+const (
+	// PriorityCritical calls (live scores) go out while any quota is left.
+	PriorityCritical Priority = iota
+	// PriorityNormal calls stop when the budget reaches the reserve.
+	PriorityNormal
+	// PriorityLow calls (bulk team lookups) stop at the larger low reserve.
+	PriorityLow
+)
+
+// APISportsClient is the one HTTP client for api-sports. It records the
+// x-ratelimit headers of every response and refuses calls the remaining
+// daily budget cannot afford.
+type APISportsClient struct {
+	baseURL    string
+	apiKey     string
+	http       *http.Client
+	quota      domain.IQuotaRepo
+	reserve    int
+	lowReserve int
+}
+
+func NewAPISportsClient(apiKey string, quota domain.IQuotaRepo, reserve, lowReserve int) *APISportsClient {
+	return &APISportsClient{
+		baseURL:    "https://v3.football.api-sports.io",
+		apiKey:     apiKey,
+		http:       &http.Client{Timeout: 12 * time.Second},
+		quota:      quota,
+		reserve:    reserve,
+		lowReserve: lowReserve,
+	}
+}
+
+// Get calls path with params and returns the raw response body. The call
+// is cancelled with ctx.
+func (c *APISportsClient) Get(ctx context.Context, path string, params url.Values, priority Priority) ([]byte, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("missing API_SPORTS_API_KEY in .env")
+	}
+	if err := c.allow(ctx, priority); err != nil {
+		return nil, err
+	}
+
+	u := c.baseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-rapidapi-key", c.apiKey)
+	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	c.record(ctx, res.Header)
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		return nil, domain.ErrQuotaExhausted
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("api error %d: %s", res.StatusCode, string(body))
+	}
+	if limitReached(body) {
+		c.exhaust(ctx)
+		return nil, domain.ErrQuotaExhausted
+	}
+
+	return body, nil
+}
+
+// Budget returns the last recorded budget.
+func (c *APISportsClient) Budget(ctx context.Context) (*domain.QuotaBudget, error) {
+	return c.quota.GetBudget(ctx, apiSportsProvider)
+}
+
+func (c *APISportsClient) allow(ctx context.Context, priority Priority) error {
+	budget, err := c.quota.GetBudget(ctx, apiSportsProvider)
+	if err != nil || budget.UpdatedAt == "" {
+		// unknown budget; let the call through and learn from its headers
+		return nil
+	}
+
+	// api-sports resets daily quotas at 00:00 UTC
+	updated, err := time.Parse(time.RFC3339, budget.UpdatedAt)
+	if err != nil || updated.UTC().Format("2006-01-02") != time.Now().UTC().Format("2006-01-02") {
+		return nil
+	}
+
+	floor := 0
+	switch priority {
+	case PriorityNormal:
+		floor = c.reserve
+	case PriorityLow:
+		floor = c.lowReserve
+	}
+
+	if budget.Remaining <= floor {
+		_ = c.quota.CountRefused(ctx, apiSportsProvider)
+		return domain.ErrQuotaExhausted
+	}
+	return nil
+}
+
+func (c *APISportsClient) record(ctx context.Context, h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("x-ratelimit-requests-remaining"))
+	if err != nil {
+		return
+	}
+
+	budget := &domain.QuotaBudget{
+		Provider:  apiSportsProvider,
+		Remaining: remaining,
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	budget.Limit, _ = strconv.Atoi(h.Get("x-ratelimit-requests-limit"))
+	budget.MinuteLimit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	budget.MinuteRemaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+
+	if err := c.quota.SaveBudget(ctx, budget); err != nil {
+		fmt.Printf("Warning: could not record api quota: %v\n", err)
+	}
+}
+
+func (c *APISportsClient) exhaust(ctx context.Context) {
+	budget, err := c.quota.GetBudget(ctx, apiSportsProvider)
+	if err != nil {
+		return
+	}
+	budget.Remaining = 0
+	budget.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	_ = c.quota.SaveBudget(ctx, budget)
+}
+
+// limitReached detects api-sports' "errors": {"requests": "..."} reply,
+// which arrives with status 200 once the daily limit is hit.
+func limitReached(body []byte) bool {
+	var envelope struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Errors) == 0 {
+		return false
+	}
+
+	var errs map[string]string
+	if err := json.Unmarshal(envelope.Errors, &errs); err != nil {
+		return false
+	}
+	_, ok := errs["requests"]
+	return ok
+}
@@ -0,0 +1,101 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// APISportsProvider serves league data from api-sports.
+type APISportsProvider struct {
+	leagues domain.ILeagueRegistry
+	client  *APISportsClient
+}
+
+func NewAPISportsProvider(leagues domain.ILeagueRegistry, client *APISportsClient) *APISportsProvider {
+	return &APISportsProvider{leagues: leagues, client: client}
+}
+
+func (p *APISportsProvider) Name() string {
+	return apiSportsProvider
+}
+
+func (p *APISportsProvider) Fixtures(ctx context.Context, league string, season int, from, to string) ([]domain.PrevFixtures, error) {
+	l, err := p.leagues.Resolve(league)
+	if err != nil {
+		return nil, err
+	}
+
+	api := APIServiceClient{leagues: p.leagues, client: p.client}
+	fixtures, err := api.PrevFixtures(ctx, l.APISportsID, season, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if fixtures == nil || len(*fixtures) == 0 {
+		return nil, domain.ErrNoData
+	}
+	return *fixtures, nil
+}
+
+func (p *APISportsProvider) Standings(ctx context.Context, league string, season int) (*domain.StandingsResponse, error) {
+	l, err := p.leagues.Resolve(league)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("league", strconv.Itoa(l.APISportsID))
+	params.Set("season", strconv.Itoa(season))
+
+	body, err := p.client.Get(ctx, "/standings", params, PriorityNormal)
+	if err != nil {
+		fmt.Println("Error making request:", err)
+		return nil, upstreamErr(err)
+	}
+
+	var apiResponse domain.StandingAPIResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	if len(apiResponse.Response) == 0 {
+		return nil, domain.ErrNoData
+	}
+
+	data := apiResponse.Response[0].League
+	standings := &domain.StandingsResponse{
+		LeagueID:   data.ID,
+		LeagueName: data.Name,
+		Country:    data.Country,
+		Season:     data.Season,
+		Provider:   apiSportsProvider,
+	}
+
+	for _, group := range data.Standings {
+		var groupStandings []domain.Standing
+		for _, t := range group {
+			groupStandings = append(groupStandings, domain.Standing{
+				Rank:          t.Rank,
+				TeamID:        t.Team.ID,
+				TeamName:      t.Team.Name,
+				TeamLogo:      t.Team.Logo,
+				Points:        t.Points,
+				GoalsFor:      t.All.Goals.For,
+				GoalsAgainst:  t.All.Goals.Against,
+				GoalsDiff:     t.GoalsDiff,
+				Form:          t.Form,
+				MatchesPlayed: t.All.Played,
+				Wins:          t.All.Win,
+				Draws:         t.All.Draw,
+				Losses:        t.All.Lose,
+			})
+		}
+		standings.Standings = groupStandings
+	}
+
+	return standings, nil
+}
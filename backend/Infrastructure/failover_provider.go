@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// FailoverProvider asks its providers in order and returns the first
+// answer. A provider that errors, is out of quota or has no data hands
+// the request to the next one.
+type FailoverProvider struct {
+	providers []domain.FootballDataProvider
+}
+
+func NewFailoverProvider(providers ...domain.FootballDataProvider) *FailoverProvider {
+	return &FailoverProvider{providers: providers}
+}
+
+func (f *FailoverProvider) Name() string {
+	names := make([]string, 0, len(f.providers))
+	for _, p := range f.providers {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+func (f *FailoverProvider) Fixtures(ctx context.Context, league string, season int, from, to string) ([]domain.PrevFixtures, error) {
+	var errs []error
+	for _, p := range f.providers {
+		fixtures, err := p.Fixtures(ctx, league, season, from, to)
+		if err == nil {
+			return fixtures, nil
+		}
+		fmt.Printf("provider %s failed for %s fixtures (season=%d): %v\n", p.Name(), league, season, err)
+		errs = append(errs, err)
+	}
+	return nil, failoverErr(errs)
+}
+
+func (f *FailoverProvider) Standings(ctx context.Context, league string, season int) (*domain.StandingsResponse, error) {
+	var errs []error
+	for _, p := range f.providers {
+		standings, err := p.Standings(ctx, league, season)
+		if err == nil {
+			return standings, nil
+		}
+		fmt.Printf("provider %s failed for %s standings (season=%d): %v\n", p.Name(), league, season, err)
+		errs = append(errs, err)
+	}
+	return nil, failoverErr(errs)
+}
+
+// failoverErr is ErrNoData only when every provider had no data. Otherwise
+// it joins the real failures, so an outage of one provider is not mistaken
+// for an empty answer when the next has no data; callers can still match
+// ErrQuotaExhausted with errors.Is.
+func failoverErr(errs []error) error {
+	var failures []error
+	for _, err := range errs {
+		if !errors.Is(err, domain.ErrNoData) {
+			failures = append(failures, err)
+		}
+	}
+	if len(failures) == 0 {
+		return domain.ErrNoData
+	}
+	return errors.Join(failures...)
+}
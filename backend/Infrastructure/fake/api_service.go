@@ -0,0 +1,277 @@
+// Package fake holds in-memory stand-ins for the upstream football APIs so
+// usecases and controllers can run with no network. The stand-ins keep
+// their data in memory, but UPSTREAM_MODE=fake still needs Redis for the
+// cache, sessions and follows.
+package fake
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// APIService is an in-memory domain.IAPIService and domain.FootballDataProvider.
+// Its fields can be changed between calls to script a match; set Err to make
+// every call fail.
+type APIService struct {
+	mu sync.Mutex
+
+	Past  []domain.PrevFixtures
+	Live  map[string][]domain.PrevFixtures // keyed by league code
+	Stats map[int]*domain.TeamComparison   // keyed by team id
+	Teams []domain.TeamInfo
+	Table *domain.StandingsResponse
+	// Players holds every seeded player; a player's squad is the team of
+	// their first statistics entry.
+	Players []domain.PlayerProfile
+	// Details holds scripted match details keyed by fixture id; other
+	// fixtures are reported with an empty timeline.
+	Details map[int]*domain.MatchDetail
+	Err     error
+}
+
+// NewAPIService returns a fake seeded with a few Ethiopian Premier League clubs.
+func NewAPIService() *APIService {
+	return &APIService{
+		Past:  seedFixtures(),
+		Live:  map[string][]domain.PrevFixtures{},
+		Stats: seedStats(),
+		Teams: seedTeams(),
+		Table: seedStandings(),
+
+		Players: seedPlayers(),
+		Details: map[int]*domain.MatchDetail{},
+	}
+}
+
+// SetLive replaces the live fixtures the fake reports for league.
+func (s *APIService) SetLive(league string, fixtures []domain.PrevFixtures) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Live[league] = fixtures
+}
+
+func (s *APIService) PrevFixtures(ctx context.Context, leagueID int, season int, fromDate, toDate string) (*[]domain.PrevFixtures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	fixtures := []domain.PrevFixtures{}
+	for _, f := range s.Past {
+		day := f.Date
+		if len(day) >= 10 {
+			day = day[:10]
+		}
+		if (fromDate != "" && day < fromDate) || (toDate != "" && day > toDate) {
+			continue
+		}
+		fixtures = append(fixtures, f)
+	}
+	return &fixtures, nil
+}
+
+func (s *APIService) LiveFixtures(ctx context.Context, league string) (*[]domain.PrevFixtures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	live := s.Live[league]
+	if len(live) == 0 {
+		return nil, nil
+	}
+	fixtures := append([]domain.PrevFixtures{}, live...)
+	return &fixtures, nil
+}
+
+func (s *APIService) Statistics(ctx context.Context, league, season, team int) (*domain.TeamComparison, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	stats, ok := s.Stats[team]
+	if !ok {
+		return nil, domain.ErrTeamNotFound
+	}
+	copied := *stats
+	return &copied, nil
+}
+
+func (s *APIService) GetTeams(ctx context.Context, leagueID, season int) (*domain.TeamsAPIResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	res := &domain.TeamsAPIResponse{
+		Get:        "teams",
+		Parameters: map[string]string{"league": strconv.Itoa(leagueID), "season": strconv.Itoa(season)},
+		Results:    len(s.Teams),
+	}
+	for _, t := range s.Teams {
+		res.Response = append(res.Response, domain.TeamResponse{Team: t})
+	}
+	return res, nil
+}
+
+func (s *APIService) HeadToHead(ctx context.Context, teamA, teamB, last int) (*[]domain.PrevFixtures, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	meetings := []domain.PrevFixtures{}
+	for i := len(s.Past) - 1; i >= 0; i-- {
+		f := s.Past[i]
+		home, away := f.HomeTeam.ID, f.AwayTeam.ID
+		if (home == teamA && away == teamB) || (home == teamB && away == teamA) {
+			meetings = append(meetings, f)
+		}
+		if last > 0 && len(meetings) == last {
+			break
+		}
+	}
+	return &meetings, nil
+}
+
+func (s *APIService) FixtureDetail(ctx context.Context, fixtureID int) (*domain.MatchDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	if d, ok := s.Details[fixtureID]; ok {
+		copied := *d
+		return &copied, nil
+	}
+
+	fixtures := s.Past
+	for _, live := range s.Live {
+		fixtures = append(fixtures[:len(fixtures):len(fixtures)], live...)
+	}
+	for _, f := range fixtures {
+		if f.FixtureID == fixtureID {
+			return &domain.MatchDetail{
+				PrevFixtures: f,
+				Events:       []domain.MatchEvent{},
+				Lineups:      []domain.Lineup{},
+				Statistics:   []domain.TeamMatchStats{},
+			}, nil
+		}
+	}
+	return nil, domain.ErrFixtureNotFound
+}
+
+func (s *APIService) Squad(ctx context.Context, teamID int) (*domain.Squad, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	var squad *domain.Squad
+	for _, p := range s.Players {
+		if len(p.Statistics) == 0 || p.Statistics[0].Team.ID != teamID {
+			continue
+		}
+		if squad == nil {
+			squad = &domain.Squad{Team: p.Statistics[0].Team}
+		}
+		number := len(squad.Players) + 1
+		squad.Players = append(squad.Players, domain.SquadPlayer{
+			ID:       p.ID,
+			Name:     p.Name,
+			Age:      p.Age,
+			Number:   &number,
+			Position: p.Statistics[0].Position,
+			Photo:    p.Photo,
+		})
+	}
+	if squad == nil {
+		return nil, domain.ErrTeamNotFound
+	}
+	return squad, nil
+}
+
+func (s *APIService) Player(ctx context.Context, playerID, season int) (*domain.PlayerProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	for _, p := range s.Players {
+		if p.ID == playerID {
+			p.Statistics = append([]domain.PlayerSeasonStats{}, p.Statistics...)
+			return &p, nil
+		}
+	}
+	return nil, domain.ErrPlayerNotFound
+}
+
+func (s *APIService) TopScorers(ctx context.Context, leagueID, season int) ([]domain.TopScorer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	scorers := []domain.TopScorer{}
+	for _, p := range s.Players {
+		if len(p.Statistics) > 0 && p.Statistics[0].Goals > 0 {
+			scorers = append(scorers, domain.TopScorer{Player: p.Player, PlayerSeasonStats: p.Statistics[0]})
+		}
+	}
+	sort.SliceStable(scorers, func(i, j int) bool {
+		if scorers[i].Goals != scorers[j].Goals {
+			return scorers[i].Goals > scorers[j].Goals
+		}
+		return scorers[i].Assists > scorers[j].Assists
+	})
+	for i := range scorers {
+		scorers[i].Rank = i + 1
+	}
+	return scorers, nil
+}
+
+func (s *APIService) Name() string {
+	return "fake"
+}
+
+func (s *APIService) Fixtures(ctx context.Context, league string, season int, from, to string) ([]domain.PrevFixtures, error) {
+	fixtures, err := s.PrevFixtures(ctx, 0, season, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(*fixtures) == 0 {
+		return nil, domain.ErrNoData
+	}
+	return *fixtures, nil
+}
+
+func (s *APIService) Standings(ctx context.Context, league string, season int) (*domain.StandingsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Err != nil {
+		return nil, s.Err
+	}
+	if s.Table == nil {
+		return nil, domain.ErrNoData
+	}
+
+	table := *s.Table
+	table.Season = season
+	table.Standings = append([]domain.Standing{}, s.Table.Standings...)
+	return &table, nil
+}
@@ -0,0 +1,69 @@
+package fake
+
+import (
+	"fmt"
+	"sync"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// EventRepository is an in-memory TheSportsDB event source for the news usecase.
+type EventRepository struct {
+	mu sync.Mutex
+
+	Past   []domain.Event
+	Future []domain.Event
+	Live   []domain.Event
+	Table  []domain.LeaguePoint
+	Err    error
+}
+
+// NewEventRepository returns a fake seeded with the demo live scores and a
+// short round of results, fixtures and a table.
+func NewEventRepository() *EventRepository {
+	return &EventRepository{
+		Past:   seedPastEvents(),
+		Future: seedFutureEvents(),
+		Live:   append([]domain.Event{}, demoLiveScores...),
+		Table:  seedLeaguePoints(),
+	}
+}
+
+func (r *EventRepository) GetPastEvents() ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return append([]domain.Event{}, r.Past...), nil
+}
+
+func (r *EventRepository) GetStandings() ([]domain.LeaguePoint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return append([]domain.LeaguePoint{}, r.Table...), nil
+}
+
+func (r *EventRepository) GetFutureEvents() ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return append([]domain.Event{}, r.Future...), nil
+}
+
+func (r *EventRepository) GetLiveScores() ([]domain.Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if len(r.Live) == 0 {
+		return []domain.Event{}, fmt.Errorf("no live scores available right now")
+	}
+	return append([]domain.Event{}, r.Live...), nil
+}
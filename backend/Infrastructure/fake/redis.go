@@ -0,0 +1,170 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis answers the string commands of the caches (GET, SET, SETNX and DEL)
+// from memory, so cache behaviour can be tested without a Redis server.
+// Any other command fails.
+type Redis struct {
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+}
+
+// NewRedis returns a client served by an empty in-memory Redis.
+func NewRedis() (*redis.Client, *Redis) {
+	store := &Redis{values: map[string]string{}, expires: map[string]time.Time{}}
+	client := redis.NewClient(&redis.Options{Addr: "fake:6379"})
+	client.AddHook(store)
+	return client, store
+}
+
+// Keys returns the live keys with the given prefix.
+func (r *Redis) Keys(prefix string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var keys []string
+	for key := range r.values {
+		if strings.HasPrefix(key, prefix) && r.liveLocked(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Age moves every expiry d closer, as if d had passed.
+func (r *Redis) Age(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, at := range r.expires {
+		r.expires[key] = at.Add(-d)
+	}
+}
+
+func (r *Redis) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, fmt.Errorf("fake redis does not dial %s", addr)
+	}
+}
+
+func (r *Redis) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			r.process(cmd)
+		}
+		return nil
+	}
+}
+
+func (r *Redis) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		r.process(cmd)
+		return cmd.Err()
+	}
+}
+
+func (r *Redis) process(cmd redis.Cmder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	args := make([]string, len(cmd.Args()))
+	for i, a := range cmd.Args() {
+		switch v := a.(type) {
+		case []byte:
+			args[i] = string(v)
+		default:
+			args[i] = fmt.Sprint(v)
+		}
+	}
+
+	switch c := cmd.(type) {
+	case *redis.StringCmd:
+		if cmd.Name() == "get" && len(args) == 2 {
+			if !r.liveLocked(args[1]) {
+				c.SetErr(redis.Nil)
+				return
+			}
+			c.SetVal(r.values[args[1]])
+			return
+		}
+	case *redis.StatusCmd:
+		if cmd.Name() == "set" && len(args) >= 3 {
+			r.setLocked(args[1], args[2], args[3:])
+			c.SetVal("OK")
+			return
+		}
+	case *redis.BoolCmd:
+		if cmd.Name() == "setnx" && len(args) == 3 {
+			c.SetVal(!r.liveLocked(args[1]))
+			if c.Val() {
+				r.setLocked(args[1], args[2], nil)
+			}
+			return
+		}
+		if cmd.Name() == "set" && len(args) >= 3 && strings.EqualFold(args[len(args)-1], "nx") {
+			if r.liveLocked(args[1]) {
+				c.SetVal(false)
+				return
+			}
+			r.setLocked(args[1], args[2], args[3:len(args)-1])
+			c.SetVal(true)
+			return
+		}
+	case *redis.IntCmd:
+		if cmd.Name() == "del" {
+			deleted := int64(0)
+			for _, key := range args[1:] {
+				if r.liveLocked(key) {
+					deleted++
+				}
+				delete(r.values, key)
+				delete(r.expires, key)
+			}
+			c.SetVal(deleted)
+			return
+		}
+	}
+	cmd.SetErr(fmt.Errorf("fake redis does not support %v", cmd.Args()))
+}
+
+// setLocked stores value under key, honouring an "ex" or "px" option.
+func (r *Redis) setLocked(key, value string, opts []string) {
+	r.values[key] = value
+	delete(r.expires, key)
+	for i := 0; i+1 < len(opts); i++ {
+		var unit time.Duration
+		switch strings.ToLower(opts[i]) {
+		case "ex":
+			unit = time.Second
+		case "px":
+			unit = time.Millisecond
+		default:
+			continue
+		}
+		var n int64
+		fmt.Sscan(opts[i+1], &n)
+		r.expires[key] = time.Now().Add(time.Duration(n) * unit)
+	}
+}
+
+func (r *Redis) liveLocked(key string) bool {
+	if _, ok := r.values[key]; !ok {
+		return false
+	}
+	if at, ok := r.expires[key]; ok && !time.Now().Before(at) {
+		delete(r.values, key)
+		delete(r.expires, key)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,279 @@
+package fake
+
+import (
+	"strconv"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const leagueName = "Ethiopian Premier League"
+
+// Demo live scores, shown until a live TheSportsDB feed is available.
+var demoLiveScores = []domain.Event{
+	{
+		IDEvent:           "1001",
+		StrEvent:          "Saint George vs Ethiopian Coffee",
+		StrEventAlternate: "St George v Coffee",
+		StrLeague:         leagueName,
+		StrSeason:         "2024/2025",
+		StrHomeTeam:       "Saint George",
+		StrAwayTeam:       "Ethiopian Coffee",
+		IntHomeScore:      "2",
+		IntAwayScore:      "1",
+		DateEvent:         "2025-09-05",
+		StrTime:           "15:30",
+		StrStatus:         "Live - 67'",
+		StrHomeTeamBadge:  "https://example.com/badges/saint_george.png",
+		StrAwayTeamBadge:  "https://example.com/badges/ethiopian_coffee.png",
+	},
+	{
+		IDEvent:           "1002",
+		StrEvent:          "Adama City vs Fasil Kenema",
+		StrEventAlternate: "Adama v Fasil",
+		StrLeague:         leagueName,
+		StrSeason:         "2024/2025",
+		StrHomeTeam:       "Adama City",
+		StrAwayTeam:       "Fasil Kenema",
+		IntHomeScore:      "0",
+		IntAwayScore:      "0",
+		DateEvent:         "2025-09-05",
+		StrTime:           "16:00",
+		StrStatus:         "Live - HT",
+		StrHomeTeamBadge:  "https://example.com/badges/adama.png",
+		StrAwayTeamBadge:  "https://example.com/badges/fasil.png",
+	},
+	{
+		IDEvent:           "1003",
+		StrEvent:          "Sidama Bunna vs Hawassa City",
+		StrEventAlternate: "Sidama v Hawassa",
+		StrLeague:         leagueName,
+		StrSeason:         "2024/2025",
+		StrHomeTeam:       "Sidama Bunna",
+		StrAwayTeam:       "Hawassa City",
+		IntHomeScore:      "1",
+		IntAwayScore:      "3",
+		DateEvent:         "2025-09-05",
+		StrTime:           "14:00",
+		StrStatus:         "Live - 80'",
+		StrHomeTeamBadge:  "https://example.com/badges/sidama.png",
+		StrAwayTeamBadge:  "https://example.com/badges/hawassa.png",
+	},
+}
+
+var seedClubs = []domain.TeamInfo{
+	{ID: 9001, Name: "Saint George", Country: "Ethiopia", Logo: "https://example.com/badges/saint_george.png"},
+	{ID: 9002, Name: "Ethiopian Coffee", Country: "Ethiopia", Logo: "https://example.com/badges/ethiopian_coffee.png"},
+	{ID: 9003, Name: "Adama City", Country: "Ethiopia", Logo: "https://example.com/badges/adama.png"},
+	{ID: 9004, Name: "Fasil Kenema", Country: "Ethiopia", Logo: "https://example.com/badges/fasil.png"},
+	{ID: 9005, Name: "Sidama Bunna", Country: "Ethiopia", Logo: "https://example.com/badges/sidama.png"},
+	{ID: 9006, Name: "Hawassa City", Country: "Ethiopia", Logo: "https://example.com/badges/hawassa.png"},
+}
+
+func seedTeams() []domain.TeamInfo {
+	return append([]domain.TeamInfo{}, seedClubs...)
+}
+
+func club(i int) domain.MTeam {
+	return domain.MTeam{ID: seedClubs[i].ID, Name: seedClubs[i].Name, Logo: seedClubs[i].Logo}
+}
+
+func goals(n int) *int {
+	return &n
+}
+
+func result(id int, date, round string, home, away, homeGoals, awayGoals int) domain.PrevFixtures {
+	g := domain.Goals{Home: goals(homeGoals), Away: goals(awayGoals)}
+	return domain.PrevFixtures{
+		FixtureID:   id,
+		Date:        date,
+		Venue:       "Addis Ababa Stadium",
+		League:      leagueName,
+		LeagueRound: "Regular Season - " + round,
+		HomeTeam:    club(home),
+		AwayTeam:    club(away),
+		Goals:       g,
+		Score:       domain.Score{Fulltime: g},
+		Status:      domain.Status{Long: "Match Finished", Short: "FT", Elapsed: 90},
+	}
+}
+
+func seedFixtures() []domain.PrevFixtures {
+	return []domain.PrevFixtures{
+		result(7001, "2022-10-22T12:00:00+00:00", "1", 0, 1, 2, 1),
+		result(7002, "2022-10-22T15:00:00+00:00", "1", 2, 3, 0, 0),
+		result(7003, "2022-10-23T12:00:00+00:00", "1", 4, 5, 1, 3),
+		result(7004, "2022-10-29T12:00:00+00:00", "2", 1, 2, 1, 1),
+		result(7005, "2022-10-29T15:00:00+00:00", "2", 3, 4, 2, 0),
+		result(7006, "2022-10-30T12:00:00+00:00", "2", 5, 0, 0, 2),
+	}
+}
+
+// seedTable is the table after the two seeded rounds.
+var seedTable = []struct {
+	club                                       int
+	played, won, drawn, lost, scored, conceded int
+}{
+	{0, 2, 2, 0, 0, 4, 1},
+	{3, 2, 1, 1, 0, 2, 0},
+	{5, 2, 1, 0, 1, 3, 3},
+	{2, 2, 0, 2, 0, 1, 1},
+	{1, 2, 0, 1, 1, 2, 3},
+	{4, 2, 0, 0, 2, 1, 5},
+}
+
+func seedStats() map[int]*domain.TeamComparison {
+	stats := map[int]*domain.TeamComparison{}
+	for _, row := range seedTable {
+		c := seedClubs[row.club]
+		stats[c.ID] = &domain.TeamComparison{
+			Name:          c.Name,
+			MatchesPlayed: row.played,
+			Wins:          row.won,
+			Draws:         row.drawn,
+			Losses:        row.lost,
+			GoalsFor:      row.scored,
+			GoalsAgainst:  row.conceded,
+		}
+	}
+	return stats
+}
+
+func seedStandings() *domain.StandingsResponse {
+	table := &domain.StandingsResponse{
+		LeagueID:   363,
+		LeagueName: leagueName,
+		Country:    "Ethiopia",
+		Season:     2022,
+		Provider:   "fake",
+	}
+	for i, row := range seedTable {
+		c := seedClubs[row.club]
+		table.Standings = append(table.Standings, domain.Standing{
+			Rank:          i + 1,
+			TeamName:      c.Name,
+			TeamLogo:      c.Logo,
+			Points:        3*row.won + row.drawn,
+			MatchesPlayed: row.played,
+			Wins:          row.won,
+			Draws:         row.drawn,
+			Losses:        row.lost,
+			GoalsFor:      row.scored,
+			GoalsAgainst:  row.conceded,
+			GoalsDiff:     row.scored - row.conceded,
+		})
+	}
+	return table
+}
+
+func seedPastEvents() []domain.Event {
+	var events []domain.Event
+	for _, f := range seedFixtures() {
+		events = append(events, domain.Event{
+			IDEvent:          f.Date[:10] + "-" + f.HomeTeam.Name,
+			StrEvent:         f.HomeTeam.Name + " vs " + f.AwayTeam.Name,
+			StrLeague:        leagueName,
+			StrSeason:        "2022-2023",
+			StrHomeTeam:      f.HomeTeam.Name,
+			StrAwayTeam:      f.AwayTeam.Name,
+			IntHomeScore:     itoa(*f.Goals.Home),
+			IntAwayScore:     itoa(*f.Goals.Away),
+			DateEvent:        f.Date[:10],
+			StrTime:          f.Date[11:19],
+			StrStatus:        "Match Finished",
+			StrHomeTeamBadge: f.HomeTeam.Logo,
+			StrAwayTeamBadge: f.AwayTeam.Logo,
+		})
+	}
+	return events
+}
+
+func seedFutureEvents() []domain.Event {
+	return []domain.Event{
+		{
+			IDEvent:          "2001",
+			StrEvent:         "Saint George vs Hawassa City",
+			StrLeague:        leagueName,
+			StrSeason:        "2022-2023",
+			StrHomeTeam:      "Saint George",
+			StrAwayTeam:      "Hawassa City",
+			DateEvent:        "2022-11-05",
+			StrTime:          "12:00:00",
+			StrStatus:        "Not Started",
+			StrHomeTeamBadge: seedClubs[0].Logo,
+			StrAwayTeamBadge: seedClubs[5].Logo,
+		},
+	}
+}
+
+func seedLeaguePoints() []domain.LeaguePoint {
+	var table []domain.LeaguePoint
+	for _, row := range seedStandings().Standings {
+		table = append(table, domain.LeaguePoint{
+			IntRank:           itoa(row.Rank),
+			StrTeam:           row.TeamName,
+			StrBadge:          row.TeamLogo,
+			StrLeague:         leagueName,
+			StrSeason:         "2022-2023",
+			IntPlayed:         itoa(row.MatchesPlayed),
+			IntWin:            itoa(row.Wins),
+			IntDraw:           itoa(row.Draws),
+			IntLoss:           itoa(row.Losses),
+			IntGoalDifference: itoa(row.GoalsDiff),
+			IntPoints:         itoa(row.Points),
+		})
+	}
+	return table
+}
+
+func itoa(n int) string {
+	return strconv.Itoa(n)
+}
+
+// seedPlayerNames are an attacker, a midfielder and a goalkeeper per club,
+// in seedClubs order.
+var seedPlayerNames = [][3]string{
+	{"Getaneh Kebede", "Gatoch Panom", "Fasil Gebremichael"},
+	{"Abubeker Nasir", "Amanuel Yohannes", "Tekle Wolde"},
+	{"Dawa Hotessa", "Bezabih Melaku", "Jemal Tassew"},
+	{"Mujib Kassim", "Surafel Dagnachew", "Syaid Habtamu"},
+	{"Yonas Gebru", "Dawit Fekadu", "Meseret Abebe"},
+	{"Tesfaye Alebachew", "Addis Hintsa", "Abel Mamo"},
+}
+
+var seedPositions = [3]string{"Attacker", "Midfielder", "Goalkeeper"}
+
+// seedPlayers splits each club's seeded goals between its attacker and
+// midfielder, who assist each other.
+func seedPlayers() []domain.PlayerProfile {
+	var players []domain.PlayerProfile
+	for _, row := range seedTable {
+		midfield := row.scored / 2
+		goals := [3]int{row.scored - midfield, midfield, 0}
+		assists := [3]int{midfield, row.scored - midfield, 0}
+
+		for k, name := range seedPlayerNames[row.club] {
+			id := 95000 + 10*row.club + k
+			players = append(players, domain.PlayerProfile{
+				Player: domain.Player{
+					ID:          id,
+					Name:        name,
+					Age:         22 + row.club + 2*k,
+					Nationality: "Ethiopia",
+					Photo:       "https://example.com/players/" + strconv.Itoa(id) + ".png",
+				},
+				Statistics: []domain.PlayerSeasonStats{{
+					Team:        club(row.club),
+					League:      leagueName,
+					Season:      2022,
+					Position:    seedPositions[k],
+					Appearances: row.played,
+					Minutes:     90 * row.played,
+					Goals:       goals[k],
+					Assists:     assists[k],
+					YellowCards: (row.club + k) % 2,
+				}},
+			})
+		}
+	}
+	return players
+}
@@ -0,0 +1,90 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+// StandingsRepo is an in-memory domain.IStandingsRepo. GetStandings serves
+// saved tables and falls back to the provider, like the Redis repo.
+type StandingsRepo struct {
+	mu        sync.Mutex
+	tables    map[string]*domain.StandingsResponse
+	snapshots map[string]map[string][]domain.Standing
+	provider  domain.FootballDataProvider
+}
+
+func NewStandingsRepo(provider domain.FootballDataProvider) *StandingsRepo {
+	return &StandingsRepo{
+		tables:    map[string]*domain.StandingsResponse{},
+		snapshots: map[string]map[string][]domain.Standing{},
+		provider:  provider,
+	}
+}
+
+func (r *StandingsRepo) GetStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	if table, err := r.GetStandingsFromCache(ctx, leagueID, season); err == nil {
+		return table, nil
+	}
+
+	return r.RefreshStandings(ctx, leagueID, season)
+}
+
+func (r *StandingsRepo) RefreshStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	table, err := r.provider.Standings(ctx, fmt.Sprint(leagueID), season)
+	if err != nil {
+		return nil, err
+	}
+	table.LeagueID = leagueID
+	snapshot := domain.StandingsSnapshot{Date: time.Now().In(ethiotime.EAT).Format("2006-01-02"), Standings: table.Standings}
+	if err := r.SaveSnapshot(ctx, leagueID, season, snapshot); err != nil {
+		return nil, err
+	}
+	return table, r.SaveStandings(ctx, leagueID, season, table)
+}
+
+func (r *StandingsRepo) SaveStandings(ctx context.Context, leagueID, season int, standings *domain.StandingsResponse) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tables[fmt.Sprintf("%d:%d", leagueID, season)] = standings
+	return nil
+}
+
+func (r *StandingsRepo) GetStandingsFromCache(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	table, ok := r.tables[fmt.Sprintf("%d:%d", leagueID, season)]
+	if !ok {
+		return nil, domain.ErrInternalServer
+	}
+	return table, nil
+}
+
+func (r *StandingsRepo) SaveSnapshot(ctx context.Context, leagueID, season int, snapshot domain.StandingsSnapshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := fmt.Sprintf("%d:%d", leagueID, season)
+	if r.snapshots[key] == nil {
+		r.snapshots[key] = map[string][]domain.Standing{}
+	}
+	r.snapshots[key][snapshot.Date] = snapshot.Standings
+	return nil
+}
+
+func (r *StandingsRepo) GetSnapshots(ctx context.Context, leagueID, season int) ([]domain.StandingsSnapshot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var snapshots []domain.StandingsSnapshot
+	for date, standings := range r.snapshots[fmt.Sprintf("%d:%d", leagueID, season)] {
+		snapshots = append(snapshots, domain.StandingsSnapshot{Date: date, Standings: standings})
+	}
+	slices.SortFunc(snapshots, func(a, b domain.StandingsSnapshot) int { return strings.Compare(a.Date, b.Date) })
+	return snapshots, nil
+}
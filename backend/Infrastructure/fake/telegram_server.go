@@ -0,0 +1,108 @@
+package fake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TelegramMessage is a message the bot sent through the fake Bot API.
+type TelegramMessage struct {
+	ChatID    int64  `json:"chat_id"`
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"`
+}
+
+// TelegramServer is a local stand-in for the Telegram Bot API. It accepts
+// sendMessage and setWebhook for one token, records what it was sent and
+// lists it at GET /messages.
+type TelegramServer struct {
+	token string
+
+	mu         sync.Mutex
+	Messages   []TelegramMessage
+	WebhookURL string
+	// RejectMarkdown makes sendMessage fail on Markdown messages the way
+	// the Bot API does when it cannot parse them.
+	RejectMarkdown bool
+}
+
+func NewTelegramServer(token string) *TelegramServer {
+	return &TelegramServer{token: token, Messages: []TelegramMessage{}}
+}
+
+func (s *TelegramServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/messages" && r.Method == http.MethodGet {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeTelegram(w, http.StatusOK, map[string]any{"ok": true, "result": s.Messages})
+		return
+	}
+
+	token, method, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/bot"), "/")
+	if !ok || !strings.HasPrefix(r.URL.Path, "/bot") {
+		telegramError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if token != s.token {
+		telegramError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	switch method {
+	case "sendMessage":
+		var msg TelegramMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil || msg.ChatID == 0 || msg.Text == "" {
+			telegramError(w, http.StatusBadRequest, "Bad Request: chat_id and text are required")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.RejectMarkdown && msg.ParseMode != "" {
+			telegramError(w, http.StatusBadRequest, "Bad Request: can't parse entities: can't find end of the entity")
+			return
+		}
+		s.Messages = append(s.Messages, msg)
+		writeTelegram(w, http.StatusOK, map[string]any{"ok": true, "result": map[string]any{
+			"message_id": len(s.Messages),
+			"chat":       map[string]any{"id": msg.ChatID},
+			"date":       time.Now().Unix(),
+			"text":       msg.Text,
+		}})
+	case "setWebhook":
+		var req struct {
+			URL string `json:"url"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			telegramError(w, http.StatusBadRequest, "Bad Request: url is required")
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.WebhookURL = req.URL
+		writeTelegram(w, http.StatusOK, map[string]any{"ok": true, "result": true, "description": "Webhook was set"})
+	default:
+		telegramError(w, http.StatusNotFound, "Not Found: method not found")
+	}
+}
+
+// Sent returns the messages sent so far.
+func (s *TelegramServer) Sent() []TelegramMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]TelegramMessage{}, s.Messages...)
+}
+
+func telegramError(w http.ResponseWriter, code int, description string) {
+	writeTelegram(w, code, map[string]any{"ok": false, "error_code": code, "description": description})
+}
+
+func writeTelegram(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
 	"os"
 	"strconv"
@@ -43,34 +41,12 @@ func RedisConnect() *redis.Client {
 //
 // Accepts:
 //
-//	league: either "EPL" (will map to 46) or "ETH" (will map to 363) or a numeric league id string
+//	leagueID: api-sports league id, resolved by the caller through the league registry
 //	team: optional team id (numeric string) — names are NOT searched here
 //	from,to: optional dates in YYYY-MM-DD
 //
-// Returns error if API key missing or upstream error.
-func FetchFixturesFromAPI(league, team, season, from, to string) ([]domain.Fixture, error) {
-	apiKey := os.Getenv("API_SPORTS_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("missing API_FOOTBALL_KEY in .env")
-	}
-
-	// Resolve league param: allow "EPL" -> 39, "ETH" -> 363, numeric IDs as passed
-	leagueID := 0
-	if league == "EPL" {
-		leagueID = 39
-	} else if league == "ETH" {
-		leagueID = 363
-	} else {
-		// try numeric
-		if n, err := strconv.Atoi(league); err == nil {
-			leagueID = n
-		} else {
-			return nil, fmt.Errorf("unknown league code: %s (use 'EPL', 'ETH', or numeric league id)", league)
-		}
-	}
-
-	base := "https://v3.football.api-sports.io"
-	endpoint := "/fixtures"
+// Returns error if API key missing, the quota is exhausted or upstream error.
+func FetchFixturesFromAPI(ctx context.Context, client *APISportsClient, leagueID int, team, season, from, to string) ([]domain.Fixture, error) {
 	params := url.Values{}
 	params.Set("league", strconv.Itoa(leagueID))
 	
@@ -92,30 +68,7 @@ func FetchFixturesFromAPI(league, team, season, from, to string) ([]domain.Fixtu
 		}
 	}
 
-	u := fmt.Sprintf("%s%s?%s", base, endpoint, params.Encode())
-
-	req, err := http.NewRequest(http.MethodGet, u, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("x-rapidapi-key", apiKey)
-	req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
-	// optional: req.Header.Set("Accept", "application/json")
-
-	client := &http.Client{Timeout: 12 * time.Second}
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer res.Body.Close()
-
-	// propagate upstream errors to caller
-	if res.StatusCode >= 400 {
-		b, _ := io.ReadAll(res.Body)
-		return nil, fmt.Errorf("api error %d: %s", res.StatusCode, string(b))
-	}
-
-	b, err := io.ReadAll(res.Body)
+	b, err := client.Get(ctx, "/fixtures", params, PriorityNormal)
 	if err != nil {
 		return nil, err
 	}
@@ -138,15 +91,18 @@ func FetchFixturesFromAPI(league, team, season, from, to string) ([]domain.Fixtu
 
 	for _, r := range apiResp.Response {
 		fixture := domain.Fixture{
-			ID:           r.Fixture.Date,
+			ID:           strconv.Itoa(r.Fixture.ID),
 			DateUTC:      r.Fixture.Date,
 			HomeName:      r.Teams.Home.Name,
 			AwayName:      r.Teams.Away.Name,
-			Status:      	"scheduled",
+			Status:      	fixtureStatus(r.Fixture.Status.Short),
 			HomeLogo:    r.Teams.Home.Logo,
 			AwayLogo:    r.Teams.Away.Logo,
 			LastUpdated: now,
 		}
+		if r.Goals.Home != nil && r.Goals.Away != nil {
+			fixture.Score = fmt.Sprintf("%d-%d", *r.Goals.Home, *r.Goals.Away)
+		}
 
 		fixtures = append(fixtures, fixture)
 	}
@@ -154,3 +110,25 @@ func FetchFixturesFromAPI(league, team, season, from, to string) ([]domain.Fixtu
 	
 	return fixtures, nil
 }
+
+// fixtureStatus maps api-sports short status codes to the coarse states
+// exposed on domain.Fixture.
+func fixtureStatus(short string) string {
+	switch short {
+	case "", "TBD", "NS":
+		return "scheduled"
+	case "FT", "AET", "PEN", "AWD", "WO":
+		// awarded and walkover matches have a result, just not one played out
+		return "finished"
+	case "1H", "HT", "2H", "ET", "BT", "P", "LIVE", "INT":
+		return "live"
+	case "SUSP":
+		return "suspended"
+	case "PST":
+		return "postponed"
+	case "CANC", "ABD":
+		return "cancelled"
+	default:
+		return "scheduled"
+	}
+}
@@ -0,0 +1,23 @@
+package infrastructure
+
+import "testing"
+
+func TestFixtureStatus(t *testing.T) {
+	for short, want := range map[string]string{
+		"":     "scheduled",
+		"NS":   "scheduled",
+		"2H":   "live",
+		"INT":  "live",
+		"SUSP": "suspended",
+		"FT":   "finished",
+		"AWD":  "finished",
+		"WO":   "finished",
+		"PST":  "postponed",
+		"CANC": "cancelled",
+		"ABD":  "cancelled",
+	} {
+		if got := fixtureStatus(short); got != want {
+			t.Errorf("fixtureStatus(%q) = %q, want %q", short, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// LeagueRegistry is an in-memory index over the leagues in the config file.
+type LeagueRegistry struct {
+	leagues []domain.LeagueConfig
+	byCode  map[string]int
+	byID    map[int]int
+	def     int
+}
+
+// LoadLeagueRegistry reads a JSON array of league configs from path.
+func LoadLeagueRegistry(path string) (*LeagueRegistry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read league config: %w", err)
+	}
+
+	var leagues []domain.LeagueConfig
+	if err := json.Unmarshal(raw, &leagues); err != nil {
+		return nil, fmt.Errorf("parse league config: %w", err)
+	}
+
+	return NewLeagueRegistry(leagues)
+}
+
+// NewLeagueRegistry builds a registry from already parsed configs.
+func NewLeagueRegistry(leagues []domain.LeagueConfig) (*LeagueRegistry, error) {
+	r := &LeagueRegistry{
+		byCode: map[string]int{},
+		byID:   map[int]int{},
+	}
+	hasDefault := false
+
+	for _, l := range leagues {
+		l.Code = strings.ToUpper(strings.TrimSpace(l.Code))
+		if l.Code == "" || l.APISportsID == 0 {
+			return nil, fmt.Errorf("league config needs both code and api_sports_id: %+v", l)
+		}
+		if _, ok := r.byCode[l.Code]; ok {
+			return nil, fmt.Errorf("duplicate league code %s", l.Code)
+		}
+		if i, ok := r.byID[l.APISportsID]; ok {
+			return nil, fmt.Errorf("leagues %s and %s share api_sports_id %d", r.leagues[i].Code, l.Code, l.APISportsID)
+		}
+		if l.Default && hasDefault {
+			return nil, fmt.Errorf("more than one default league: %s and %s", r.leagues[r.def].Code, l.Code)
+		}
+
+		r.leagues = append(r.leagues, l)
+		r.byCode[l.Code] = len(r.leagues) - 1
+		r.byID[l.APISportsID] = len(r.leagues) - 1
+		if l.Default {
+			r.def = len(r.leagues) - 1
+			hasDefault = true
+		}
+	}
+
+	if len(r.leagues) == 0 {
+		return nil, fmt.Errorf("league config lists no leagues")
+	}
+	return r, nil
+}
+
+func (r *LeagueRegistry) Resolve(codeOrID string) (*domain.LeagueConfig, error) {
+	if l, err := r.ByCode(codeOrID); err == nil {
+		return l, nil
+	}
+	if id, err := strconv.Atoi(strings.TrimSpace(codeOrID)); err == nil {
+		return r.ByAPISportsID(id)
+	}
+	return nil, domain.ErrLeagueNotFound
+}
+
+func (r *LeagueRegistry) ByCode(code string) (*domain.LeagueConfig, error) {
+	i, ok := r.byCode[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return nil, domain.ErrLeagueNotFound
+	}
+	l := r.leagues[i]
+	return &l, nil
+}
+
+func (r *LeagueRegistry) ByAPISportsID(id int) (*domain.LeagueConfig, error) {
+	i, ok := r.byID[id]
+	if !ok {
+		return nil, domain.ErrLeagueNotFound
+	}
+	l := r.leagues[i]
+	return &l, nil
+}
+
+func (r *LeagueRegistry) All() []domain.LeagueConfig {
+	out := make([]domain.LeagueConfig, len(r.leagues))
+	copy(out, r.leagues)
+	return out
+}
+
+func (r *LeagueRegistry) Default() domain.LeagueConfig {
+	return r.leagues[r.def]
+}
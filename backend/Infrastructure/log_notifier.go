@@ -0,0 +1,42 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// LogNotifier writes notifications as JSON lines, for local testing.
+type LogNotifier struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewLogNotifier appends to the file at path, or writes to stdout when path is empty.
+func NewLogNotifier(path string) (*LogNotifier, error) {
+	if path == "" {
+		return &LogNotifier{out: os.Stdout}, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &LogNotifier{out: f}, nil
+}
+
+func (l *LogNotifier) Notify(ctx context.Context, n domain.Notification) error {
+	line, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.out.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,195 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// OpenAIChatClient talks to any OpenAI-compatible /chat/completions
+// endpoint: OpenAI itself, Ollama (http://localhost:11434/v1), llama.cpp,
+// vLLM or a local stub server.
+type OpenAIChatClient struct {
+	baseURL string
+	apiKey  string
+	model   string
+	http    *http.Client
+}
+
+// NewOpenAIChatClient builds a client for baseURL. apiKey may be empty for
+// self-hosted servers.
+func NewOpenAIChatClient(baseURL, apiKey, model string) *OpenAIChatClient {
+	return &OpenAIChatClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		http:    &http.Client{Timeout: 2 * time.Minute},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+	Stream         bool              `json:"stream"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// Complete sends prompt as a single user message and returns the reply.
+// With jsonMode the server is asked for a JSON object.
+func (c *OpenAIChatClient) Complete(ctx context.Context, prompt string, jsonMode bool) (string, error) {
+	reqBody := chatRequest{
+		Model:    c.model,
+		Messages: []chatMessage{{Role: "user", Content: prompt}},
+	}
+	if jsonMode {
+		reqBody.ResponseFormat = map[string]string{"type": "json_object"}
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("llm error %d: %s", res.StatusCode, string(body))
+	}
+
+	var parsed chatResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", err
+	}
+	if len(parsed.Choices) == 0 || parsed.Choices[0].Message.Content == "" {
+		return "", fmt.Errorf("llm returned no content")
+	}
+	return parsed.Choices[0].Message.Content, nil
+}
+
+// OpenAIAnswerComposer is a domain.AnswerComposer backed by an
+// OpenAI-compatible chat model.
+type OpenAIAnswerComposer struct {
+	client *OpenAIChatClient
+}
+
+func NewOpenAIAnswerComposer(client *OpenAIChatClient) *OpenAIAnswerComposer {
+	return &OpenAIAnswerComposer{client: client}
+}
+
+func (c *OpenAIAnswerComposer) ComposeAnswer(dCtx domain.AnswerContext) (*domain.Answer, error) {
+	markdown, err := c.client.Complete(context.Background(), answerPrompt(dCtx), false)
+	if err != nil {
+		fmt.Println("err : ", err)
+		return nil, domain.ErrUnexpected
+	}
+
+	return &domain.Answer{
+		Markdown:  markdown,
+		Source:    dCtx.Source,
+		Freshness: dCtx.Freshness,
+	}, nil
+}
+
+// OpenAIIntentParser is an intent parser backed by an OpenAI-compatible
+// chat model. Unlike Gemini these servers cannot enforce a response schema,
+// so the schema is spelled out in the prompt and checked on the way back.
+type OpenAIIntentParser struct {
+	client  *OpenAIChatClient
+	teams   domain.ITeamDirectory
+	leagues domain.ILeagueRegistry
+}
+
+func NewOpenAIIntentParser(client *OpenAIChatClient, teams domain.ITeamDirectory, leagues domain.ILeagueRegistry) *OpenAIIntentParser {
+	return &OpenAIIntentParser{client: client, teams: teams, leagues: leagues}
+}
+
+const intentJSONInstructions = `
+
+Reply with ONLY a JSON object of this shape and nothing else:
+{"topic": one of "fixture" | "table" | "compare" | "news" | "fact" | "player",
+ "teams": [team names, possibly empty],
+ "league": one of %s,
+ "date": optional date,
+ "follow_up": optional follow-up question,
+ "language": "english" or "amharic"}`
+
+// intentInstructions spells out the reply schema with the configured
+// league codes.
+func intentInstructions(leagues domain.ILeagueRegistry) string {
+	var quoted []string
+	for _, code := range leagueCodes(leagues) {
+		quoted = append(quoted, fmt.Sprintf("%q", code))
+	}
+	return fmt.Sprintf(intentJSONInstructions, strings.Join(quoted, " | "))
+}
+
+func (ip *OpenAIIntentParser) Parse(text string) (*domain.Intent, error) {
+	reply, err := ip.client.Complete(context.Background(), intentPrompt(text, leagueListPrompt(ip.leagues), teamListPrompt(ip.teams, ip.leagues))+intentInstructions(ip.leagues), true)
+	if err != nil {
+		log.Print(err)
+		return nil, domain.ErrUnexpected
+	}
+
+	var parsed domain.Intent
+	if err := json.Unmarshal([]byte(stripCodeFence(reply)), &parsed); err != nil {
+		log.Print(err)
+		return nil, domain.ErrUnexpected
+	}
+	if !slices.Contains(intentTopics, parsed.Topic) {
+		log.Printf("llm returned unknown intent topic %q", parsed.Topic)
+		return nil, domain.ErrUnexpected
+	}
+	if parsed.Teams == nil {
+		parsed.Teams = []string{}
+	}
+
+	return &parsed, nil
+}
+
+// stripCodeFence removes the ```json fences small models like to add.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimPrefix(s, "json")
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "```"))
+}
@@ -0,0 +1,114 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// Prompts shared by every LLM backend, so switching models does not change
+// what the assistant is asked to do.
+
+// intentTopics are the topics an intent parser may return.
+var intentTopics = []string{"fixture", "table", "compare", "news", "fact", "player"}
+
+func answerPrompt(dCtx domain.AnswerContext) string {
+	data := dCtx.ContextData
+	if len(dCtx.Dates) > 0 {
+		data = make(map[string]interface{}, len(dCtx.ContextData)+1)
+		for k, v := range dCtx.ContextData {
+			data[k] = v
+		}
+		data["kickoff_times"] = dCtx.Dates
+	}
+	contextBytes, _ := json.MarshalIndent(data, "", "  ")
+
+	// language := "English"
+	// if dCtx.Language == "am" {
+	// 	language = "Amharic"
+	// }
+
+	return fmt.Sprintf(`You are a helpful and concise football assistant for Ethiopian fans.
+	Your task is to write a short, friendly summary in %s using ONLY the data provided below.
+
+	**Rules:**
+	- Use ONLY the provided data. Do not make up scores, fixtures, or facts.
+	- If a piece of information is missing from the data, say "it is not available" or "is not confirmed."
+	- The output MUST be markdown.
+	- The tone should be friendly and respectful of all clubs.
+	- NO betting or gambling language.
+	- When mentioning a match date or kickoff time, use its "local" value from "kickoff_times" instead of the raw UTC date.
+	- For Compare outline which season the stats are from, using the "season" field of the data
+	- For Compare, when "head_to_head" is present, cite the record and the scores of the latest meetings
+
+	**Provided Data (JSON format):**
+	%s
+
+	Now, write the summary:`, dCtx.Language, string(contextBytes))
+}
+
+// intentPrompt builds the parser prompt; leagues comes from leagueListPrompt
+// and teams from teamListPrompt.
+func intentPrompt(text, leagues, teams string) string {
+	return `System Prompt: Detect League Context: When a user asks about a match, standings, results, 
+			live scores, or any league-related query, identify that the request is 
+			about football.Default assumption: unless a specific league is mentioned, provide 
+			information for the leagues below in the listed order. Returns only the short code 
+			of the league, one of the codes in brackets, and keep the order consistent with the 
+			list: 
+
+` + leagues + `
+			Language Handling: If the user writes in 
+			Amharic or explicitly wants to interact in Amharic, all responses, including headings 
+			and match details, should be in Amharic. Include a field in the response 'language': 
+			'amharic'. If the user writes in English or wants English responses, respond in English 
+			and set language": "english. Auto-detect language preference from the user prompt and if 
+			the langaue is amaharic change translate the language into english for the intent but the 
+			language field should not be changed since will need it for answer. Use the topic 
+			'player' for questions about players, squads, top scorers, assists or cards. the name of the team 
+			you insert to intent should be in one of the following.  
+
+` + teams + "\n\nuser prompt" + text
+}
+
+// leagueCodes lists the configured league codes, the default league first.
+func leagueCodes(leagues domain.ILeagueRegistry) []string {
+	def := leagues.Default().Code
+	codes := []string{def}
+	for _, l := range leagues.All() {
+		if l.Code != def {
+			codes = append(codes, l.Code)
+		}
+	}
+	return codes
+}
+
+// leagueListPrompt names every configured league with its code for the
+// intent parser prompt, the default league first.
+func leagueListPrompt(leagues domain.ILeagueRegistry) string {
+	var b strings.Builder
+	for i, code := range leagueCodes(leagues) {
+		l, _ := leagues.ByCode(code)
+		fmt.Fprintf(&b, "%d. %s (%s)\n", i+1, l.Name("en"), l.Code)
+	}
+	return b.String()
+}
+
+// teamListPrompt lists the canonical team names of every league for the
+// intent parser prompt.
+func teamListPrompt(teams domain.ITeamDirectory, leagues domain.ILeagueRegistry) string {
+	var b strings.Builder
+	for _, l := range leagues.All() {
+		var names []string
+		for _, t := range teams.Teams(l.Code) {
+			names = append(names, fmt.Sprintf("%q", t.Name))
+		}
+		if len(names) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "teams for %s (%s): [%s]\n", l.Name("en"), l.Code, strings.Join(names, ", "))
+	}
+	return b.String()
+}
@@ -0,0 +1,201 @@
+package infrastructure
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ReplayRecord passes requests upstream and saves every response to disk.
+	ReplayRecord = "record"
+	// ReplayOnly answers from disk and fails requests that were never recorded.
+	ReplayOnly = "replay"
+)
+
+// recordedHeaders are kept with a recording; the quota client reads them.
+var recordedHeaders = []string{
+	"Content-Type",
+	"x-ratelimit-requests-limit",
+	"x-ratelimit-requests-remaining",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+}
+
+// secretParams are query parameters that carry API keys.
+var secretParams = []string{"key", "api_key", "apikey", "token"}
+
+// redactedURL is the request URL without its API keys: secret query
+// parameters are dropped and TheSportsDB's key segment in
+// /api/v1/json/{key}/ is blanked. Recordings are named and saved under it,
+// so none holds a key and a recording replays whatever key is configured.
+func redactedURL(u *url.URL) string {
+	r := *u
+	r.User = nil
+
+	if segments := strings.Split(r.Path, "/"); len(segments) > 4 && segments[1] == "api" && segments[3] == "json" {
+		segments[4] = "KEY"
+		r.Path = strings.Join(segments, "/")
+		r.RawPath = ""
+	}
+
+	query := r.Query()
+	for _, name := range secretParams {
+		query.Del(name)
+	}
+	r.RawQuery = query.Encode()
+	return r.String()
+}
+
+// ReplayTransport records upstream HTTP responses to a directory once and
+// replays them later, so the backend can run with no network. Only requests
+// to the listed hosts are recorded; everything else goes straight to next.
+type ReplayTransport struct {
+	mode  string
+	dir   string
+	hosts map[string]bool
+	next  http.RoundTripper
+}
+
+type recording struct {
+	Method string            `json:"method"`
+	URL    string            `json:"url"`
+	Status int               `json:"status"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   json.RawMessage   `json:"body,omitempty"`
+	Text   string            `json:"text,omitempty"` // bodies that are not JSON
+}
+
+func NewReplayTransport(mode, dir string, next http.RoundTripper, hosts ...string) (*ReplayTransport, error) {
+	if mode != ReplayRecord && mode != ReplayOnly {
+		return nil, fmt.Errorf("unknown replay mode %q", mode)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	t := &ReplayTransport{mode: mode, dir: dir, hosts: map[string]bool{}, next: next}
+	for _, h := range hosts {
+		t.hosts[h] = true
+	}
+	return t, nil
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.hosts[req.URL.Hostname()] {
+		return t.next.RoundTrip(req)
+	}
+
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	path := t.path(req, reqBody)
+
+	if t.mode == ReplayOnly {
+		return t.replay(req, path)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.save(req, res, body, path); err != nil {
+		fmt.Printf("Warning: could not record %s: %v\n", redactedURL(req.URL), err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	return res, nil
+}
+
+// path names a recording after the request host and a hash of its method,
+// redacted URL and body.
+func (t *ReplayTransport) path(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + redactedURL(req.URL) + "\n"))
+	h.Write(body)
+	name := strings.ReplaceAll(req.URL.Hostname(), ".", "_") + "_" + hex.EncodeToString(h.Sum(nil))[:16] + ".json"
+	return filepath.Join(t.dir, name)
+}
+
+func (t *ReplayTransport) save(req *http.Request, res *http.Response, body []byte, path string) error {
+	rec := recording{
+		Method: req.Method,
+		URL:    redactedURL(req.URL),
+		Status: res.StatusCode,
+		Header: map[string]string{},
+	}
+	for _, name := range recordedHeaders {
+		if v := res.Header.Get(name); v != "" {
+			rec.Header[name] = v
+		}
+	}
+	if json.Valid(body) {
+		rec.Body = body
+	} else {
+		rec.Text = string(body)
+	}
+
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (t *ReplayTransport) replay(req *http.Request, path string) (*http.Response, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("no recording for %s %s: %w", req.Method, redactedURL(req.URL), err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("bad recording %s: %v", path, err)
+	}
+
+	body := []byte(rec.Text)
+	if len(rec.Body) > 0 {
+		body = rec.Body
+	}
+
+	header := http.Header{}
+	for k, v := range rec.Header {
+		header.Set(k, v)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package infrastructure_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+)
+
+// upstream answers every request with a canned body keyed by URL path and
+// remembers the URLs it was asked for.
+type upstream struct {
+	mu     sync.Mutex
+	bodies map[string]string
+	header http.Header
+	urls   []string
+}
+
+func (u *upstream) RoundTrip(req *http.Request) (*http.Response, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.urls = append(u.urls, req.URL.String())
+
+	body, ok := u.bodies[req.URL.Path]
+	if !ok {
+		return nil, errors.New("unexpected upstream call to " + req.URL.Path)
+	}
+	header := u.header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func (u *upstream) calls() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return len(u.urls)
+}
+
+// useTransport routes the package's HTTP clients through rt for the test.
+func useTransport(t *testing.T, rt http.RoundTripper) {
+	t.Helper()
+	saved := http.DefaultTransport
+	http.DefaultTransport = rt
+	t.Cleanup(func() { http.DefaultTransport = saved })
+}
+
+func replayTransport(t *testing.T, mode, dir string, next http.RoundTripper) *infrastructure.ReplayTransport {
+	t.Helper()
+	rt, err := infrastructure.NewReplayTransport(mode, dir, next, "v3.football.api-sports.io", "www.thesportsdb.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rt
+}
+
+func testLeagues(t *testing.T) domain.ILeagueRegistry {
+	t.Helper()
+	leagues, err := infrastructure.LoadLeagueRegistry("../config/leagues.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leagues
+}
+
+func TestReplayStripsSportsDBKey(t *testing.T) {
+	dir := t.TempDir()
+	leagues := testLeagues(t)
+	up := &upstream{bodies: map[string]string{
+		"/api/v1/json/paid-key/lookuptable.php": `{"table":[{"intRank":"1","idTeam":"133","strTeam":"Saint George","intPoints":"30","intPlayed":"12"}]}`,
+	}}
+
+	useTransport(t, replayTransport(t, infrastructure.ReplayRecord, dir, up))
+	recorded, err := infrastructure.NewSportsDBProvider(leagues, "paid-key").Standings(context.Background(), "ETH", 2023)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("recorded %d files, want 1", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "paid-key") {
+		t.Errorf("recording holds the API key:\n%s", data)
+	}
+
+	// the recording answers a provider configured with another key
+	useTransport(t, replayTransport(t, infrastructure.ReplayOnly, dir, up))
+	replayed, err := infrastructure.NewSportsDBProvider(leagues, "").Standings(context.Background(), "ETH", 2023)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up.calls() != 1 {
+		t.Errorf("upstream called %d times, want only while recording", up.calls())
+	}
+	if replayed.Standings[0].TeamName != recorded.Standings[0].TeamName || replayed.Standings[0].Points != 30 {
+		t.Errorf("replayed table = %+v, want %+v", replayed.Standings, recorded.Standings)
+	}
+}
+
+func TestReplayAPISportsLiveFixtures(t *testing.T) {
+	dir := t.TempDir()
+	leagues := testLeagues(t)
+	up := &upstream{
+		bodies: map[string]string{
+			"/fixtures": `{"response":[{
+				"fixture":{"id":7,"date":"2024-03-02T13:00:00+00:00","status":{"short":"2H","elapsed":67}},
+				"league":{"name":"Premier League","round":"Regular Season - 18"},
+				"teams":{"home":{"id":1,"name":"Saint George"},"away":{"id":2,"name":"Ethiopian Coffee"}},
+				"goals":{"home":2,"away":1}
+			}]}`,
+		},
+		header: http.Header{"X-Ratelimit-Requests-Remaining": {"88"}, "X-Ratelimit-Requests-Limit": {"100"}},
+	}
+
+	useTransport(t, replayTransport(t, infrastructure.ReplayRecord, dir, up))
+	quota := &memoryQuota{}
+	api := infrastructure.NewAPIService(leagues, infrastructure.NewAPISportsClient("k", quota, 10, 40))
+	if _, err := api.LiveFixtures(context.Background(), "ETH"); err != nil {
+		t.Fatal(err)
+	}
+
+	useTransport(t, replayTransport(t, infrastructure.ReplayOnly, dir, up))
+	quota = &memoryQuota{}
+	api = infrastructure.NewAPIService(leagues, infrastructure.NewAPISportsClient("k", quota, 10, 40))
+	live, err := api.LiveFixtures(context.Background(), "ETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if live == nil || len(*live) != 1 {
+		t.Fatalf("live = %v, want one match", live)
+	}
+	m := (*live)[0]
+	if m.HomeTeam.Name != "Saint George" || *m.Goals.Home != 2 || *m.Goals.Away != 1 || m.Status.Elapsed != 67 {
+		t.Errorf("replayed match = %+v", m)
+	}
+	if quota.budget == nil || quota.budget.Remaining != 88 {
+		t.Errorf("budget from the replayed headers = %+v, want 88 remaining", quota.budget)
+	}
+	if up.calls() != 1 {
+		t.Errorf("upstream called %d times, want only while recording", up.calls())
+	}
+
+	// a request that was never recorded fails instead of going upstream
+	if _, err := api.LiveFixtures(context.Background(), "EPL"); err == nil {
+		t.Error("unrecorded request succeeded in replay mode")
+	}
+	if up.calls() != 1 {
+		t.Errorf("replay mode went upstream")
+	}
+}
+
+type memoryQuota struct {
+	budget *domain.QuotaBudget
+}
+
+func (q *memoryQuota) GetBudget(ctx context.Context, provider string) (*domain.QuotaBudget, error) {
+	if q.budget == nil {
+		return &domain.QuotaBudget{Provider: provider}, nil
+	}
+	copied := *q.budget
+	return &copied, nil
+}
+
+func (q *memoryQuota) SaveBudget(ctx context.Context, budget *domain.QuotaBudget) error {
+	copied := *budget
+	q.budget = &copied
+	return nil
+}
+
+func (q *memoryQuota) CountRefused(ctx context.Context, provider string) error {
+	return nil
+}
@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// LoadReportTemplates reads the per-language news phrasing from path.
+func LoadReportTemplates(path string) (domain.ReportTemplates, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read report templates: %w", err)
+	}
+
+	var templates domain.ReportTemplates
+	if err := json.Unmarshal(raw, &templates); err != nil {
+		return nil, fmt.Errorf("parse report templates: %w", err)
+	}
+	return templates, nil
+}
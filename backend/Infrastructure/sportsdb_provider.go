@@ -0,0 +1,225 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const sportsDBProvider = "thesportsdb"
+
+// SportsDBProvider serves league data from TheSportsDB. Its team and event
+// ids differ from api-sports ids, so mapped fixtures leave them unset.
+type SportsDBProvider struct {
+	baseURL string
+	leagues domain.ILeagueRegistry
+	http    *http.Client
+}
+
+// NewSportsDBProvider uses apiKey for TheSportsDB, falling back to the free test key.
+func NewSportsDBProvider(leagues domain.ILeagueRegistry, apiKey string) *SportsDBProvider {
+	if apiKey == "" {
+		apiKey = "123"
+	}
+	return &SportsDBProvider{
+		baseURL: "https://www.thesportsdb.com/api/v1/json/" + apiKey,
+		leagues: leagues,
+		http:    &http.Client{Timeout: 12 * time.Second},
+	}
+}
+
+func (p *SportsDBProvider) Name() string {
+	return sportsDBProvider
+}
+
+func (p *SportsDBProvider) Fixtures(ctx context.Context, league string, season int, from, to string) ([]domain.PrevFixtures, error) {
+	l, err := p.resolve(league)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("id", l.SportsDBID)
+	params.Set("s", sportsDBSeason(l, season))
+
+	var result struct {
+		Events []domain.Event `json:"events"`
+	}
+	if err := p.get(ctx, "/eventsseason.php", params, &result); err != nil {
+		return nil, err
+	}
+
+	var fixtures []domain.PrevFixtures
+	for _, e := range result.Events {
+		day := e.DateEvent
+		if (from != "" && day < from) || (to != "" && day > to) {
+			continue
+		}
+		fixtures = append(fixtures, sportsDBFixture(e))
+	}
+	if len(fixtures) == 0 {
+		return nil, domain.ErrNoData
+	}
+	return fixtures, nil
+}
+
+func (p *SportsDBProvider) Standings(ctx context.Context, league string, season int) (*domain.StandingsResponse, error) {
+	l, err := p.resolve(league)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("l", l.SportsDBID)
+	params.Set("s", sportsDBSeason(l, season))
+
+	var result struct {
+		Table []domain.LeaguePoint `json:"table"`
+	}
+	if err := p.get(ctx, "/lookuptable.php", params, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Table) == 0 {
+		return nil, domain.ErrNoData
+	}
+
+	standings := &domain.StandingsResponse{
+		LeagueID:    l.APISportsID,
+		LeagueName:  result.Table[0].StrLeague,
+		Country:     l.Country,
+		Season:      season,
+		LastUpdated: result.Table[0].DateUpdated,
+		Provider:    sportsDBProvider,
+	}
+	// TeamID is left 0: TheSportsDB's team ids would be taken for
+	// api-sports ones, so its rows are matched by team name
+	for _, row := range result.Table {
+		standings.Standings = append(standings.Standings, domain.Standing{
+			Rank:          atoi(row.IntRank),
+			TeamName:      row.StrTeam,
+			TeamLogo:      row.StrBadge,
+			Points:        atoi(row.IntPoints),
+			GoalsFor:      atoi(row.IntGoalsFor),
+			GoalsAgainst:  atoi(row.IntGoalsAgainst),
+			GoalsDiff:     atoi(row.IntGoalDifference),
+			Form:          row.StrForm,
+			MatchesPlayed: atoi(row.IntPlayed),
+			Wins:          atoi(row.IntWin),
+			Draws:         atoi(row.IntDraw),
+			Losses:        atoi(row.IntLoss),
+		})
+	}
+	return standings, nil
+}
+
+func (p *SportsDBProvider) resolve(league string) (*domain.LeagueConfig, error) {
+	l, err := p.leagues.Resolve(league)
+	if err != nil {
+		return nil, err
+	}
+	if l.SportsDBID == "" {
+		return nil, fmt.Errorf("league %s has no TheSportsDB id: %w", l.Code, domain.ErrLeagueNotFound)
+	}
+	return l, nil
+}
+
+func (p *SportsDBProvider) get(ctx context.Context, path string, params url.Values, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %v", path, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+	if res.StatusCode == http.StatusTooManyRequests {
+		return domain.ErrQuotaExhausted
+	}
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("thesportsdb error %d: %s", res.StatusCode, string(body))
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		return fmt.Errorf("failed to unmarshal %s JSON: %v", path, err)
+	}
+	return nil
+}
+
+// sportsDBSeason formats a season the way TheSportsDB names it: "2023-2024"
+// for leagues that span two years and "2023" for calendar-year leagues.
+func sportsDBSeason(l *domain.LeagueConfig, season int) string {
+	if l.Calendar.StartMonth > l.Calendar.EndMonth {
+		return fmt.Sprintf("%d-%d", season, season+1)
+	}
+	return strconv.Itoa(season)
+}
+
+func sportsDBFixture(e domain.Event) domain.PrevFixtures {
+	f := domain.PrevFixtures{
+		Date:     sportsDBDate(e),
+		Venue:    e.StrVenue,
+		League:   e.StrLeague,
+		HomeTeam: domain.MTeam{Name: e.StrHomeTeam, Logo: e.StrHomeTeamBadge},
+		AwayTeam: domain.MTeam{Name: e.StrAwayTeam, Logo: e.StrAwayTeamBadge},
+		Status:   sportsDBStatus(e.StrStatus),
+	}
+	if e.IntRound != "" {
+		// same shape as api-sports rounds so round grouping works for both
+		f.LeagueRound = "Regular Season - " + e.IntRound
+	}
+	if home, err := strconv.Atoi(e.IntHomeScore); err == nil {
+		f.Goals.Home = &home
+	}
+	if away, err := strconv.Atoi(e.IntAwayScore); err == nil {
+		f.Goals.Away = &away
+	}
+	f.Score.Fulltime = f.Goals
+	return f
+}
+
+// sportsDBDate returns the kickoff as RFC3339; TheSportsDB times are UTC.
+func sportsDBDate(e domain.Event) string {
+	for _, v := range []string{e.StrTimestamp, e.DateEvent + "T" + e.StrTime} {
+		if t, err := time.Parse("2006-01-02T15:04:05", strings.TrimSuffix(v, "Z")); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return e.DateEvent
+}
+
+// sportsDBStatus maps TheSportsDB status text to api-sports short codes.
+func sportsDBStatus(s string) domain.Status {
+	switch strings.ToLower(s) {
+	case "match finished", "ft":
+		return domain.Status{Long: "Match Finished", Short: "FT"}
+	case "aet":
+		return domain.Status{Long: "Match Finished After Extra Time", Short: "AET"}
+	case "pen":
+		return domain.Status{Long: "Match Finished After Penalty", Short: "PEN"}
+	case "postponed":
+		return domain.Status{Long: "Match Postponed", Short: "PST"}
+	case "", "not started", "ns":
+		return domain.Status{Long: "Not Started", Short: "NS"}
+	}
+	return domain.Status{Long: s, Short: s}
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
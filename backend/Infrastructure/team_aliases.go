@@ -0,0 +1,23 @@
+package infrastructure
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// LoadTeamAliases reads the seed list of teams and their aliases from path.
+func LoadTeamAliases(path string) ([]domain.TeamAlias, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read team aliases: %w", err)
+	}
+
+	var teams []domain.TeamAlias
+	if err := json.Unmarshal(raw, &teams); err != nil {
+		return nil, fmt.Errorf("parse team aliases: %w", err)
+	}
+	return teams, nil
+}
@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// DefaultTelegramAPIURL is the public Bot API; tests point the client at a
+// local fake instead.
+const DefaultTelegramAPIURL = "https://api.telegram.org"
+
+// telegramMaxText is the longest message the Bot API accepts.
+const telegramMaxText = 4096
+
+// errTelegramMarkup is returned when the Bot API cannot parse the
+// Markdown of a message.
+var errTelegramMarkup = errors.New("telegram: can't parse message markup")
+
+// TelegramClient calls the Bot API methods the bot needs.
+type TelegramClient struct {
+	apiURL string
+	token  string
+	client *http.Client
+}
+
+func NewTelegramClient(apiURL, token string) *TelegramClient {
+	if apiURL == "" {
+		apiURL = DefaultTelegramAPIURL
+	}
+	return &TelegramClient{
+		apiURL: strings.TrimRight(apiURL, "/"),
+		token:  token,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *TelegramClient) SendMessage(ctx context.Context, chatID int64, text string) error {
+	if r := []rune(text); len(r) > telegramMaxText {
+		text = string(r[:telegramMaxText-1]) + "…"
+	}
+
+	err := c.call(ctx, "sendMessage", map[string]any{"chat_id": chatID, "text": text, "parse_mode": "Markdown"})
+	if errors.Is(err, errTelegramMarkup) {
+		// answers are written as common Markdown, which the Bot API's
+		// dialect does not always accept
+		err = c.call(ctx, "sendMessage", map[string]any{"chat_id": chatID, "text": text})
+	}
+	return err
+}
+
+// SetWebhook asks the Bot API to POST updates to url, sending secret in
+// the X-Telegram-Bot-Api-Secret-Token header.
+func (c *TelegramClient) SetWebhook(ctx context.Context, url, secret string) error {
+	params := map[string]any{"url": url, "allowed_updates": []string{"message"}}
+	if secret != "" {
+		params["secret_token"] = secret
+	}
+	return c.call(ctx, "setWebhook", params)
+}
+
+func (c *TelegramClient) call(ctx context.Context, method string, params map[string]any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/bot%s/%s", c.apiURL, c.token, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		// the URL holds the token, so it is kept out of the error
+		return fmt.Errorf("telegram %s: %w", method, errors.Unwrap(err))
+	}
+	defer res.Body.Close()
+
+	var reply struct {
+		OK          bool   `json:"ok"`
+		ErrorCode   int    `json:"error_code"`
+		Description string `json:"description"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&reply); err != nil {
+		return fmt.Errorf("telegram %s: status %d", method, res.StatusCode)
+	}
+	if !reply.OK {
+		if reply.ErrorCode == http.StatusBadRequest && strings.Contains(reply.Description, "parse entities") {
+			return errTelegramMarkup
+		}
+		return fmt.Errorf("telegram %s error %d: %s", method, reply.ErrorCode, reply.Description)
+	}
+	return nil
+}
+
+// TelegramNotifier delivers the notifications of follows made through the
+// bot to their chat and hands every other notification to next, if any.
+type TelegramNotifier struct {
+	sender domain.IChatSender
+	next   domain.Notifier
+}
+
+func NewTelegramNotifier(sender domain.IChatSender, next domain.Notifier) *TelegramNotifier {
+	return &TelegramNotifier{sender: sender, next: next}
+}
+
+func (n *TelegramNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	chat, ok := strings.CutPrefix(notification.UserID, domain.TelegramUserPrefix)
+	if !ok {
+		if n.next == nil {
+			return nil
+		}
+		return n.next.Notify(ctx, notification)
+	}
+
+	chatID, err := strconv.ParseInt(chat, 10, 64)
+	if err != nil {
+		return fmt.Errorf("telegram notification: invalid user id %q", notification.UserID)
+	}
+	return n.sender.SendMessage(ctx, chatID, "*"+notification.Title+"*\n"+notification.Body)
+}
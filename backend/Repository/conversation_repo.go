@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewConversationRepo(rdb *redis.Client) domain.IConversationRepo {
+	return &ConversationRepo{rdb: rdb}
+}
+
+type ConversationRepo struct {
+	rdb *redis.Client
+}
+
+// key -> "conv:{id}" -> Conversation JSON, expiring ttl after the last turn
+func conversationKey(id string) string { return fmt.Sprintf("conv:%s", id) }
+
+func (r *ConversationRepo) GetConversation(ctx context.Context, id string) (*domain.Conversation, error) {
+	raw, err := r.rdb.Get(ctx, conversationKey(id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrConversationNotFound
+		}
+		return nil, domain.ErrInternalServer
+	}
+
+	var conv domain.Conversation
+	if err := json.Unmarshal(raw, &conv); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return &conv, nil
+}
+
+func (r *ConversationRepo) SaveConversation(ctx context.Context, conv *domain.Conversation, ttl time.Duration) error {
+	payload, err := json.Marshal(conv)
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	if err := r.rdb.Set(ctx, conversationKey(conv.ID), payload, ttl).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
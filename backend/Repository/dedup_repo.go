@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewDedupRepo(rdb *redis.Client, prefix string) domain.IDedupRepo {
+	return &DedupRepo{rdb: rdb, prefix: prefix}
+}
+
+type DedupRepo struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+// key -> "{prefix}:{key}"
+func (r *DedupRepo) FirstSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, r.prefix+":"+key, 1, ttl).Result()
+	if err != nil {
+		return false, domain.ErrInternalServer
+	}
+	return ok, nil
+}
+
+func (r *DedupRepo) Forget(ctx context.Context, key string) error {
+	if err := r.rdb.Del(ctx, r.prefix+":"+key).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
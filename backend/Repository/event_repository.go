@@ -15,11 +15,12 @@ type EventRepositoryImpl struct {
 	apiFutureURL   string
 }
 
-func NewEventRepository() *EventRepositoryImpl {
+// NewEventRepository reads TheSportsDB data for the league with the given TheSportsDB id.
+func NewEventRepository(sportsDBID string) *EventRepositoryImpl {
 	return &EventRepositoryImpl{
-		apiURL:         "https://www.thesportsdb.com/api/v1/json/123/eventspastleague.php?id=4959",
-		apiStandingURL: "https://www.thesportsdb.com/api/v1/json/123/lookuptable.php?l=4959",
-		apiFutureURL:   "https://www.thesportsdb.com/api/v1/json/123/eventsnextleague.php?id=4959",
+		apiURL:         fmt.Sprintf("https://www.thesportsdb.com/api/v1/json/123/eventspastleague.php?id=%s", sportsDBID),
+		apiStandingURL: fmt.Sprintf("https://www.thesportsdb.com/api/v1/json/123/lookuptable.php?l=%s", sportsDBID),
+		apiFutureURL:   fmt.Sprintf("https://www.thesportsdb.com/api/v1/json/123/eventsnextleague.php?id=%s", sportsDBID),
 	}
 }
 
@@ -97,61 +98,8 @@ func (r *EventRepositoryImpl) GetFutureEvents() ([]domain.Event, error) {
 	return result.Events, nil
 }
 
-// --- Demo Live Scores until API is found ---
-var demoLiveScores = []domain.Event{
-	{
-		IDEvent:           "1001",
-		StrEvent:          "Saint George vs Ethiopian Coffee",
-		StrEventAlternate: "St George v Coffee",
-		StrLeague:         "Ethiopian Premier League",
-		StrSeason:         "2024/2025",
-		StrHomeTeam:       "Saint George",
-		StrAwayTeam:       "Ethiopian Coffee",
-		IntHomeScore:      "2",
-		IntAwayScore:      "1",
-		DateEvent:         "2025-09-05",
-		StrTime:           "15:30",
-		StrStatus:         "Live - 67'",
-		StrHomeTeamBadge:  "https://example.com/badges/saint_george.png",
-		StrAwayTeamBadge:  "https://example.com/badges/ethiopian_coffee.png",
-	},
-	{
-		IDEvent:           "1002",
-		StrEvent:          "Adama City vs Fasil Kenema",
-		StrEventAlternate: "Adama v Fasil",
-		StrLeague:         "Ethiopian Premier League",
-		StrSeason:         "2024/2025",
-		StrHomeTeam:       "Adama City",
-		StrAwayTeam:       "Fasil Kenema",
-		IntHomeScore:      "0",
-		IntAwayScore:      "0",
-		DateEvent:         "2025-09-05",
-		StrTime:           "16:00",
-		StrStatus:         "Live - HT",
-		StrHomeTeamBadge:  "https://example.com/badges/adama.png",
-		StrAwayTeamBadge:  "https://example.com/badges/fasil.png",
-	},
-	{
-		IDEvent:           "1003",
-		StrEvent:          "Sidama Bunna vs Hawassa City",
-		StrEventAlternate: "Sidama v Hawassa",
-		StrLeague:         "Ethiopian Premier League",
-		StrSeason:         "2024/2025",
-		StrHomeTeam:       "Sidama Bunna",
-		StrAwayTeam:       "Hawassa City",
-		IntHomeScore:      "1",
-		IntAwayScore:      "3",
-		DateEvent:         "2025-09-05",
-		StrTime:           "14:00",
-		StrStatus:         "Live - 80'",
-		StrHomeTeamBadge:  "https://example.com/badges/sidama.png",
-		StrAwayTeamBadge:  "https://example.com/badges/hawassa.png",
-	},
-}
-
+// GetLiveScores has no TheSportsDB feed behind it yet; the offline fake
+// in Infrastructure/fake serves demo live scores instead.
 func (r *EventRepositoryImpl) GetLiveScores() ([]domain.Event, error) {
-	if len(demoLiveScores) == 0 {
-		return []domain.Event{}, fmt.Errorf("no live scores available right now")
-	}
-	return demoLiveScores, nil
+	return []domain.Event{}, fmt.Errorf("no live scores available right now")
 }
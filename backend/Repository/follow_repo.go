@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewFollowRepo(rdb *redis.Client) domain.IFollowRepo {
+	return &FollowRepo{rdb: rdb}
+}
+
+type FollowRepo struct {
+	rdb *redis.Client
+}
+
+// Keys:
+//
+//	"follows:{user}"      -> set of followed team IDs
+//	"follows:meta:{user}" -> hash teamID -> FollowedTeam JSON
+//	"followers:{team}"    -> set of user IDs following the team
+func followsKey(userID string) string     { return fmt.Sprintf("follows:%s", userID) }
+func followsMetaKey(userID string) string { return fmt.Sprintf("follows:meta:%s", userID) }
+func followersKey(teamID string) string   { return fmt.Sprintf("followers:%s", teamID) }
+
+func (r *FollowRepo) Follow(ctx context.Context, userID string, follow domain.FollowedTeam) error {
+	payload, err := json.Marshal(follow)
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, followsKey(userID), follow.TeamID)
+		pipe.HSet(ctx, followsMetaKey(userID), follow.TeamID, payload)
+		pipe.SAdd(ctx, followersKey(follow.TeamID), userID)
+		return nil
+	})
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *FollowRepo) Unfollow(ctx context.Context, userID, teamID string) error {
+	removed, err := r.rdb.SRem(ctx, followsKey(userID), teamID).Result()
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	if removed == 0 {
+		return domain.ErrFollowNotFound
+	}
+
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, followsMetaKey(userID), teamID)
+		pipe.SRem(ctx, followersKey(teamID), userID)
+		return nil
+	})
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *FollowRepo) List(ctx context.Context, userID string) ([]domain.FollowedTeam, error) {
+	teamIDs, err := r.rdb.SMembers(ctx, followsKey(userID)).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	sort.Strings(teamIDs)
+
+	meta, err := r.rdb.HGetAll(ctx, followsMetaKey(userID)).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	follows := make([]domain.FollowedTeam, 0, len(teamIDs))
+	for _, id := range teamIDs {
+		follow := domain.FollowedTeam{TeamID: id}
+		if raw, ok := meta[id]; ok {
+			_ = json.Unmarshal([]byte(raw), &follow)
+		}
+		follows = append(follows, follow)
+	}
+	return follows, nil
+}
+
+func (r *FollowRepo) Get(ctx context.Context, userID, teamID string) (*domain.FollowedTeam, error) {
+	raw, err := r.rdb.HGet(ctx, followsMetaKey(userID), teamID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrFollowNotFound
+		}
+		return nil, domain.ErrInternalServer
+	}
+
+	var follow domain.FollowedTeam
+	if err := json.Unmarshal([]byte(raw), &follow); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return &follow, nil
+}
+
+func (r *FollowRepo) Followers(ctx context.Context, teamID string) ([]string, error) {
+	users, err := r.rdb.SMembers(ctx, followersKey(teamID)).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return users, nil
+}
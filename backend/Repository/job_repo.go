@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// kickoffsTTL drops the kickoff list of a league the worker stopped
+// refreshing.
+const kickoffsTTL = 8 * 24 * time.Hour
+
+func NewJobRepo(rdb *redis.Client) domain.IJobRepo {
+	return &JobRepo{rdb: rdb}
+}
+
+type JobRepo struct {
+	rdb *redis.Client
+}
+
+// Keys:
+//
+//	"worker:lease:{job}"       -> held by the replica running the job
+//	"worker:last:{job}"        -> RFC 3339 time of the last completed run
+//	"worker:kickoffs:{league}" -> JSON list of kickoff times
+func (r *JobRepo) Claim(ctx context.Context, job string, ttl time.Duration) (bool, error) {
+	ok, err := r.rdb.SetNX(ctx, fmt.Sprintf("worker:lease:%s", job), time.Now().UTC().Format(time.RFC3339), ttl).Result()
+	if err != nil {
+		return false, domain.ErrInternalServer
+	}
+	return ok, nil
+}
+
+func (r *JobRepo) Release(ctx context.Context, job string) error {
+	if err := r.rdb.Del(ctx, fmt.Sprintf("worker:lease:%s", job)).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *JobRepo) LastRun(ctx context.Context, job string) (time.Time, error) {
+	val, err := r.rdb.Get(ctx, fmt.Sprintf("worker:last:%s", job)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return time.Time{}, nil
+		}
+		return time.Time{}, domain.ErrInternalServer
+	}
+
+	at, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return time.Time{}, nil
+	}
+	return at, nil
+}
+
+func (r *JobRepo) SetLastRun(ctx context.Context, job string, at time.Time) error {
+	if err := r.rdb.Set(ctx, fmt.Sprintf("worker:last:%s", job), at.UTC().Format(time.RFC3339), 0).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *JobRepo) SaveKickoffs(ctx context.Context, league string, kickoffs []time.Time) error {
+	payload, err := json.Marshal(kickoffs)
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	if err := r.rdb.Set(ctx, fmt.Sprintf("worker:kickoffs:%s", league), payload, kickoffsTTL).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *JobRepo) Kickoffs(ctx context.Context, league string) ([]time.Time, error) {
+	raw, err := r.rdb.Get(ctx, fmt.Sprintf("worker:kickoffs:%s", league)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, domain.ErrInternalServer
+	}
+
+	var kickoffs []time.Time
+	if err := json.Unmarshal(raw, &kickoffs); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return kickoffs, nil
+}
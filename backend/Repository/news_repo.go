@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// newsTTL is how long a story stays in the feed.
+const newsTTL = 90 * 24 * time.Hour
+
+func NewNewsRepo(rdb *redis.Client) domain.INewsRepo {
+	return &NewsRepo{rdb: rdb}
+}
+
+type NewsRepo struct {
+	rdb *redis.Client
+}
+
+// Keys:
+//
+//	"news:item:{id}"        -> NewsItem JSON
+//	"news:feed"             -> sorted set of every item ID by publish time
+//	"news:league:{league}"  -> sorted set of the league's item IDs
+//	"news:team:{team}"      -> sorted set of the team's item IDs
+func newsItemKey(id string) string       { return fmt.Sprintf("news:item:%s", id) }
+func newsLeagueKey(league string) string { return fmt.Sprintf("news:league:%s", league) }
+func newsTeamKey(team string) string     { return fmt.Sprintf("news:team:%s", strings.ToLower(team)) }
+
+const newsFeedKey = "news:feed"
+
+func (r *NewsRepo) SaveNews(ctx context.Context, item domain.NewsItem) error {
+	if existing, err := r.GetNews(ctx, item.ID); err == nil {
+		item.PublishedAt = existing.PublishedAt
+		item.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	published, err := time.Parse(time.RFC3339, item.PublishedAt)
+	if err != nil {
+		return fmt.Errorf("news item %s: invalid published_at: %w", item.ID, err)
+	}
+
+	payload, err := json.Marshal(item)
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+
+	indexes := []string{newsFeedKey}
+	if item.League != "" {
+		indexes = append(indexes, newsLeagueKey(item.League))
+	}
+	for _, team := range item.Teams {
+		indexes = append(indexes, newsTeamKey(team))
+	}
+
+	expired := strconv.FormatInt(time.Now().Add(-newsTTL).Unix(), 10)
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, newsItemKey(item.ID), payload, newsTTL)
+		for _, key := range indexes {
+			pipe.ZAdd(ctx, key, redis.Z{Score: float64(published.Unix()), Member: item.ID})
+			pipe.ZRemRangeByScore(ctx, key, "-inf", "("+expired)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *NewsRepo) GetNews(ctx context.Context, id string) (*domain.NewsItem, error) {
+	raw, err := r.rdb.Get(ctx, newsItemKey(id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, domain.ErrNewsNotFound
+		}
+		return nil, domain.ErrInternalServer
+	}
+
+	var item domain.NewsItem
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	return &item, nil
+}
+
+// ListNews pages through the most specific index of q, newest first. When
+// both a team and a league are given the team index is paged and the league
+// is checked per item, so such a page may hold fewer than Limit items.
+func (r *NewsRepo) ListNews(ctx context.Context, q domain.NewsQuery) ([]domain.NewsItem, error) {
+	key := newsFeedKey
+	switch {
+	case q.Team != "":
+		key = newsTeamKey(q.Team)
+	case q.League != "":
+		key = newsLeagueKey(q.League)
+	}
+
+	from := "-inf"
+	if !q.Since.IsZero() {
+		from = strconv.FormatInt(q.Since.Unix(), 10)
+	}
+	ids, err := r.rdb.ZRevRangeByScore(ctx, key, &redis.ZRangeBy{
+		Min:    from,
+		Max:    "+inf",
+		Offset: int64(q.Offset),
+		Count:  int64(q.Limit),
+	}).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	if len(ids) == 0 {
+		return []domain.NewsItem{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = newsItemKey(id)
+	}
+	values, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	items := make([]domain.NewsItem, 0, len(values))
+	for _, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue // expired since it was indexed
+		}
+		var item domain.NewsItem
+		if err := json.Unmarshal([]byte(raw), &item); err != nil {
+			continue
+		}
+		if q.Team != "" && q.League != "" && !strings.EqualFold(item.League, q.League) {
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
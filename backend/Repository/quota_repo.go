@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewQuotaRepo(rdb *redis.Client) domain.IQuotaRepo {
+	return &QuotaRepo{rdb: rdb}
+}
+
+type QuotaRepo struct {
+	rdb *redis.Client
+}
+
+// refusedTTL keeps yesterday's refusal count readable past midnight.
+const refusedTTL = 48 * time.Hour
+
+// key -> "quota:{provider}" -> hash of QuotaBudget fields
+func quotaKey(provider string) string { return fmt.Sprintf("quota:%s", provider) }
+
+// key -> "quota:{provider}:refused:{YYYY-MM-DD}" -> calls refused that UTC
+// day, so the count starts over when the daily budget resets
+func refusedKey(provider string, day time.Time) string {
+	return fmt.Sprintf("quota:%s:refused:%s", provider, day.UTC().Format("2006-01-02"))
+}
+
+func (r *QuotaRepo) GetBudget(ctx context.Context, provider string) (*domain.QuotaBudget, error) {
+	vals, err := r.rdb.HGetAll(ctx, quotaKey(provider)).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+	if len(vals) == 0 {
+		// nothing recorded yet; an empty UpdatedAt marks the budget as unknown
+		return &domain.QuotaBudget{Provider: provider}, nil
+	}
+
+	atoi := func(k string) int {
+		n, _ := strconv.Atoi(vals[k])
+		return n
+	}
+	return &domain.QuotaBudget{
+		Provider:        provider,
+		Limit:           atoi("limit"),
+		Remaining:       atoi("remaining"),
+		MinuteLimit:     atoi("minute_limit"),
+		MinuteRemaining: atoi("minute_remaining"),
+		Refused:         r.refused(ctx, provider),
+		UpdatedAt:       vals["updated_at"],
+	}, nil
+}
+
+func (r *QuotaRepo) refused(ctx context.Context, provider string) int {
+	n, err := r.rdb.Get(ctx, refusedKey(provider, time.Now())).Int()
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func (r *QuotaRepo) SaveBudget(ctx context.Context, budget *domain.QuotaBudget) error {
+	err := r.rdb.HSet(ctx, quotaKey(budget.Provider), map[string]interface{}{
+		"limit":            budget.Limit,
+		"remaining":        budget.Remaining,
+		"minute_limit":     budget.MinuteLimit,
+		"minute_remaining": budget.MinuteRemaining,
+		"updated_at":       budget.UpdatedAt,
+	}).Err()
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *QuotaRepo) CountRefused(ctx context.Context, provider string) error {
+	key := refusedKey(provider, time.Now())
+	_, err := r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, refusedTTL)
+		return nil
+	})
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
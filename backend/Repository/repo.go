@@ -20,10 +20,6 @@ type teamRepo struct {
 	rdb *redis.Client
 }
 
-func(tr *teamRepo) CacheTeamID(ctx context.Context, teamName string, teamID string) error{
-	return tr.rdb.Set(ctx, teamName, teamID, 0).Err()	
-}
-
 func (tr *teamRepo) Get(ctx context.Context, teamId string) (*domain.Team, error) {
 	key := "team:" + teamId
 	vals, err := tr.rdb.HGetAll(ctx, key).Result()
@@ -73,22 +69,6 @@ func (tr *teamRepo) Add(ctx context.Context, team *domain.Team) error {
 	return nil
 }
 
-func (tr *teamRepo) GetID(ctx context.Context, team string) (int, error) {
-	val, err := tr.rdb.Get(ctx, team).Result()
-	if err != nil {
-		if err == redis.Nil {
-			return 0, domain.ErrTeamNotFound
-		}
-		return 0, domain.ErrInternalServer
-	}
-
-	id, err := strconv.Atoi(val)
-	if err != nil {
-		return 0, domain.ErrInternalServer
-	}
-	return id, nil
-}
-
 func (tr *teamRepo) GetTeamByID(ctx context.Context, teamID int) (*domain.Team, error) {
 	key := fmt.Sprintf("team:%d", teamID)
 	vals, err := tr.rdb.HGetAll(ctx, key).Result()
@@ -216,63 +196,34 @@ func (tr *teamRepo) SaveTeamStats(ctx context.Context, teamID int, stats *domain
 
 // FixtureRepo abstracts fixture fetching
 type FixtureRepo interface {
-	GetFixtures(league, team, season, from, to string) ([]domain.Fixture, error)
+	GetFixtures(ctx context.Context, league, team, season, from, to string) ([]domain.Fixture, error)
 }
 
 // APIRepo fetches fixtures from API and caches in Redis
 type APIRepo struct {
-	RDB *redis.Client // exported for usecase
+	cache   *SWRCache
+	leagues domain.ILeagueRegistry
+	client  *infrastructure.APISportsClient
 }
 
-// NewAPIRepo returns a repo with optional Redis caching
-func NewAPIRepo(rdb *redis.Client) *APIRepo {
-	return &APIRepo{RDB: rdb}
+// NewAPIRepo returns a repo with stale-while-revalidate Redis caching
+func NewAPIRepo(cache *SWRCache, leagues domain.ILeagueRegistry, client *infrastructure.APISportsClient) *APIRepo {
+	return &APIRepo{cache: cache, leagues: leagues, client: client}
 }
 
 func cacheKey(league, team, season, from, to string) string {
 	return fmt.Sprintf("fixtures:%s:%s:%s:%s:%s", league, team, season, from, to)
 }
 
-func (r *APIRepo) GetFixtures(league, team, season, from, to string) ([]domain.Fixture, error) {
-	ctx := context.Background()
-
-	// Try cache first
-	if r.RDB != nil {
-		if raw, err := r.RDB.Get(ctx, cacheKey(league, team, season, from, to)).Result(); err == nil {
-			var cached []domain.Fixture
-			if err := json.Unmarshal([]byte(raw), &cached); err == nil {
-				return cached, nil
-			}
-			// continue if unmarshal fails
-		}
-	}
-
-	// Fetch from API
-	fixtures, err := infrastructure.FetchFixturesFromAPI(league, team, season, from, to)
+func (r *APIRepo) GetFixtures(ctx context.Context, league, team, season, from, to string) ([]domain.Fixture, error) {
+	l, err := r.leagues.Resolve(league)
 	if err != nil {
-		return []domain.Fixture{}, nil
-	}
-
-	// Cache result for 5 minutes (best-effort)
-	if r.RDB != nil {
-		if b, err := json.Marshal(fixtures); err == nil {
-			_ = r.RDB.Set(ctx, cacheKey(league, team, season, from, to), b, 5*time.Minute).Err()
-		}
+		return nil, err
 	}
 
-	return fixtures, nil
-}
-
-// SetFixturesCache manually writes fixtures to cache (optional)
-func (r *APIRepo) SetFixturesCache(league, team, season, from, to string, fixtures []domain.Fixture) error {
-	if r.RDB == nil {
-		return nil
-	}
-
-	ctx := context.Background()
-	data, err := json.Marshal(fixtures)
-	if err != nil {
-		return err
-	}
-	return r.RDB.Set(ctx, cacheKey(league, team, season, from, to), data, 5*time.Minute).Err()
+	// Fresh for 5 minutes, then served stale for up to a week while refreshed
+	policy := CachePolicy{FreshFor: 5 * time.Minute, StaleFor: 7 * 24 * time.Hour}
+	return Fetch(ctx, r.cache, cacheKey(league, team, season, from, to), policy, func(ctx context.Context) ([]domain.Fixture, error) {
+		return infrastructure.FetchFixturesFromAPI(ctx, r.client, l.APISportsID, team, season, from, to)
+	})
 }
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewSeasonRepo(rdb *redis.Client) domain.ISeasonRepo {
+	return &SeasonRepo{rdb: rdb}
+}
+
+type SeasonRepo struct {
+	rdb *redis.Client
+}
+
+// key -> "season:current:{league}"
+func (r *SeasonRepo) GetCurrentSeason(ctx context.Context, league string) (int, error) {
+	val, err := r.rdb.Get(ctx, fmt.Sprintf("season:current:%s", league)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, domain.ErrSeasonNotFound
+		}
+		return 0, domain.ErrInternalServer
+	}
+
+	season, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, domain.ErrInternalServer
+	}
+	return season, nil
+}
+
+func (r *SeasonRepo) SaveCurrentSeason(ctx context.Context, league string, season int, ttl time.Duration) error {
+	key := fmt.Sprintf("season:current:%s", league)
+	if err := r.rdb.Set(ctx, key, season, ttl).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+// key -> "season:window:{league}:{season}" -> {from,to}
+func (r *SeasonRepo) GetSeasonWindow(ctx context.Context, league string, season int) (string, string, error) {
+	raw, err := r.rdb.Get(ctx, fmt.Sprintf("season:window:%s:%d", league, season)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return "", "", domain.ErrSeasonNotFound
+		}
+		return "", "", domain.ErrInternalServer
+	}
+
+	var v struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", "", domain.ErrInternalServer
+	}
+	return v.From, v.To, nil
+}
+
+func (r *SeasonRepo) SaveSeasonWindow(ctx context.Context, league string, season int, from, to string) error {
+	payload, err := json.Marshal(map[string]string{"from": from, "to": to})
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+
+	key := fmt.Sprintf("season:window:%s:%d", league, season)
+	if err := r.rdb.Set(ctx, key, payload, 0).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
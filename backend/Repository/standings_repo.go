@@ -4,119 +4,71 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
 	"github.com/redis/go-redis/v9"
 )
 
 type StandingsRepo struct {
-	apiURL string
-	rdb    *redis.Client
+	rdb      *redis.Client
+	provider domain.FootballDataProvider
+	cache    *SWRCache
 }
 
-func NewStandingsRepo(rdb *redis.Client) domain.IStandingsRepo {
+func NewStandingsRepo(rdb *redis.Client, cache *SWRCache, provider domain.FootballDataProvider) domain.IStandingsRepo {
 	return &StandingsRepo{
-		apiURL: "https://v3.football.api-sports.io/standings",
-		rdb:    rdb,
+		rdb:      rdb,
+		provider: provider,
+		cache:    cache,
 	}
 }
 
+// Standings are fresh for 6 hours and served stale for up to 30 days while refreshed.
+var standingsPolicy = CachePolicy{FreshFor: 6 * time.Hour, StaleFor: 30 * 24 * time.Hour}
 
 func (r *StandingsRepo) GetStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	key := fmt.Sprintf("st:%d:%d", leagueID, season)
+	return Fetch(ctx, r.cache, key, standingsPolicy, func(ctx context.Context) (*domain.StandingsResponse, error) {
+		return r.fetchStandings(ctx, leagueID, season)
+	})
+}
 
-    key := fmt.Sprintf("st:%d:%d", leagueID, season)
-    // Try cache first
-    cachedData, err := r.rdb.Get(ctx, key).Bytes()
-    if err == nil {
-        var standingsResponse domain.StandingsResponse
-        if err := json.Unmarshal(cachedData, &standingsResponse); err == nil {
-            return &standingsResponse, nil
-        }
-    } else if err != redis.Nil {
-        fmt.Printf("Redis error: %v\n", err)
-    }
-
-    // Fetch from API
-    API_KEY := os.Getenv("API_SPORTS_API_KEY")
-    url := fmt.Sprintf("%s?league=%d&season=%d", r.apiURL, leagueID, season)
-
-    client := &http.Client{}
-    req, err := http.NewRequest(http.MethodGet, url, nil)
-    if err != nil {
-        fmt.Println("Error creating request:", err)
-        return nil, domain.ErrInternalServer
-    }
-
-    req.Header.Set("x-rapidapi-key", API_KEY)
-    req.Header.Set("x-rapidapi-host", "v3.football.api-sports.io")
-
-    res, err := client.Do(req)
-    if err != nil {
-        fmt.Println("Error making request:", err)
-        return nil, domain.ErrInternalServer
-    }
-    defer res.Body.Close()
-
-    body, err := io.ReadAll(res.Body)
-    if err != nil {
-        fmt.Println("Error reading response:", err)
-        return nil, domain.ErrInternalServer
-    }
-
-    var apiResponse domain.StandingAPIResponse
-    if err := json.Unmarshal(body, &apiResponse); err != nil {
-        return nil, domain.ErrInternalServer
-    }
-
-    if len(apiResponse.Response) == 0 {
-        return nil, fmt.Errorf("no standings found")
-    }
-
-    league := apiResponse.Response[0].League
-    simplified := &domain.StandingsResponse{
-        LeagueID:   league.ID,
-        LeagueName: league.Name,
-        Country:    league.Country,
-        Season:     league.Season,
-    }
-
-    for _, group := range league.Standings {
-        var groupStandings []domain.Standing
-        for _, t := range group {
-            groupStandings = append(groupStandings, domain.Standing{
-                Rank:          t.Rank,
-                TeamName:      t.Team.Name,
-                TeamLogo:      t.Team.Logo,
-                Points:        t.Points,
-                GoalsDiff:     t.GoalsDiff,
-                MatchesPlayed: t.All.Played,
-                Wins:          t.All.Win,
-                Draws:         t.All.Draw,
-                Losses:        t.All.Lose,
-            })
-        }
-        simplified.Standings = groupStandings
-    }
-
-    if err := r.SaveStandings(ctx, leagueID, season, simplified); err != nil {
-        fmt.Printf("Warning: could not save to cache: %v\n", err)
-    }
-
-    return simplified, nil
+func (r *StandingsRepo) RefreshStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	table, err := r.fetchStandings(ctx, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	if table == nil || len(table.Standings) == 0 {
+		return table, nil
+	}
+	return table, r.SaveStandings(ctx, leagueID, season, table)
 }
 
+func (r *StandingsRepo) fetchStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	table, err := r.provider.Standings(ctx, strconv.Itoa(leagueID), season)
+	if err != nil || table == nil || len(table.Standings) == 0 {
+		return table, err
+	}
 
-func (r *StandingsRepo) SaveStandings(ctx context.Context, leagueID, season int, standings *domain.StandingsResponse) error {
-	key := fmt.Sprintf("st:%d:%d", leagueID, season)
-	payload, err := json.Marshal(standings)
-	if err != nil {
-		return domain.ErrDuplicateFound
+	// every upstream refresh also becomes the snapshot of the day
+	snapshot := domain.StandingsSnapshot{
+		Date:      time.Now().In(ethiotime.EAT).Format("2006-01-02"),
+		Standings: table.Standings,
+	}
+	if err := r.SaveSnapshot(ctx, leagueID, season, snapshot); err != nil {
+		fmt.Printf("Warning: could not save standings snapshot of league %d: %v\n", leagueID, err)
 	}
+	return table, nil
+}
 
-	if err := r.rdb.Set(ctx, key, payload, 0).Err(); err != nil {
+func (r *StandingsRepo) SaveStandings(ctx context.Context, leagueID, season int, standings *domain.StandingsResponse) error {
+	key := fmt.Sprintf("st:%d:%d", leagueID, season)
+	if err := r.cache.Store(ctx, key, standings, standingsPolicy.ttl()); err != nil {
 		return domain.ErrInternalServer
 	}
 	return nil
@@ -124,18 +76,44 @@ func (r *StandingsRepo) SaveStandings(ctx context.Context, leagueID, season int,
 
 func (r *StandingsRepo) GetStandingsFromCache(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
 	key := fmt.Sprintf("st:%d:%d", leagueID, season)
-	cachedData, err := r.rdb.Get(ctx, key).Bytes()
-	if err != nil {
-		if err == redis.Nil {
-			return nil, domain.ErrInternalServer
-		}
+	var standingsResponse domain.StandingsResponse
+	if _, err := r.cache.Load(ctx, key, &standingsResponse); err != nil {
 		return nil, domain.ErrInternalServer
 	}
 
-	var standingsResponse domain.StandingsResponse
-	if err := json.Unmarshal(cachedData, &standingsResponse); err != nil {
+	return &standingsResponse, nil
+}
+
+// key -> "st:hist:{leagueID}:{season}" -> hash of date -> standings
+func (r *StandingsRepo) SaveSnapshot(ctx context.Context, leagueID, season int, snapshot domain.StandingsSnapshot) error {
+	payload, err := json.Marshal(snapshot.Standings)
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("st:hist:%d:%d", leagueID, season)
+	if err := r.rdb.HSet(ctx, key, snapshot.Date, payload).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *StandingsRepo) GetSnapshots(ctx context.Context, leagueID, season int) ([]domain.StandingsSnapshot, error) {
+	key := fmt.Sprintf("st:hist:%d:%d", leagueID, season)
+	days, err := r.rdb.HGetAll(ctx, key).Result()
+	if err != nil {
 		return nil, domain.ErrInternalServer
 	}
 
-	return &standingsResponse, nil
+	snapshots := make([]domain.StandingsSnapshot, 0, len(days))
+	for date, raw := range days {
+		snapshot := domain.StandingsSnapshot{Date: date}
+		if err := json.Unmarshal([]byte(raw), &snapshot.Standings); err != nil {
+			fmt.Printf("skipping unreadable standings snapshot %s %s: %v\n", key, date, err)
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	slices.SortFunc(snapshots, func(a, b domain.StandingsSnapshot) int { return strings.Compare(a.Date, b.Date) })
+	return snapshots, nil
 }
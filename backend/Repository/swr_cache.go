@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// CachePolicy says how long an entry is served as fresh and for how long
+// after that it may still be served stale while it is refreshed. A zero
+// StaleFor keeps the entry in Redis forever.
+type CachePolicy struct {
+	FreshFor time.Duration
+	StaleFor time.Duration
+}
+
+func (p CachePolicy) ttl() time.Duration {
+	if p.StaleFor == 0 {
+		return 0
+	}
+	return p.FreshFor + p.StaleFor
+}
+
+// SWRCache is a stale-while-revalidate cache over Redis. Entries are kept
+// in an envelope with their fetch time so every read can report a CacheMeta.
+type SWRCache struct {
+	rdb *redis.Client
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+}
+
+type swrEntry struct {
+	Data      json.RawMessage `json:"data"`
+	FetchedAt time.Time       `json:"fetched_at"`
+}
+
+func NewSWRCache(rdb *redis.Client) *SWRCache {
+	return &SWRCache{rdb: rdb, refreshing: map[string]bool{}}
+}
+
+// Store writes value under key as freshly fetched from upstream.
+func (c *SWRCache) Store(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(swrEntry{Data: data, FetchedAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, key, payload, ttl).Err()
+}
+
+// Load reads key into dst and records its CacheMeta on ctx. It returns
+// redis.Nil when the key is missing or not in the envelope format.
+func (c *SWRCache) Load(ctx context.Context, key string, dst any) (time.Time, error) {
+	raw, err := c.rdb.Get(ctx, key).Bytes()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var entry swrEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.FetchedAt.IsZero() {
+		return time.Time{}, redis.Nil
+	}
+	if err := json.Unmarshal(entry.Data, dst); err != nil {
+		return time.Time{}, redis.Nil
+	}
+
+	domain.RecordCacheMeta(ctx, domain.CacheMeta{
+		Key:         key,
+		Source:      "cache",
+		FreshnessTS: entry.FetchedAt.Format(time.RFC3339),
+	})
+	return entry.FetchedAt, nil
+}
+
+// Fetch returns the cached value for key. Fresh entries are returned as is;
+// stale entries are returned right away while fetch refreshes them in the
+// background; missing entries are fetched synchronously. The CacheMeta of
+// the returned value is recorded on ctx.
+func Fetch[T any](ctx context.Context, c *SWRCache, key string, policy CachePolicy, fetch func(ctx context.Context) (T, error)) (T, error) {
+	return FetchByValue(ctx, c, key, func(T) CachePolicy { return policy }, fetch)
+}
+
+// FetchByValue is Fetch for entries whose lifetime depends on their content,
+// such as a match that changes by the minute while live and never once
+// finished. policyOf picks the policy of each cached or fetched value.
+func FetchByValue[T any](ctx context.Context, c *SWRCache, key string, policyOf func(T) CachePolicy, fetch func(ctx context.Context) (T, error)) (T, error) {
+	var cached T
+	raw, err := c.rdb.Get(ctx, key).Bytes()
+	if err == nil {
+		var entry swrEntry
+		if json.Unmarshal(raw, &entry) == nil && !entry.FetchedAt.IsZero() && json.Unmarshal(entry.Data, &cached) == nil {
+			policy := policyOf(cached)
+			stale := policy.FreshFor > 0 && time.Since(entry.FetchedAt) > policy.FreshFor
+			domain.RecordCacheMeta(ctx, domain.CacheMeta{
+				Key:         key,
+				Source:      "cache",
+				FreshnessTS: entry.FetchedAt.Format(time.RFC3339),
+				Stale:       stale,
+			})
+			if stale {
+				c.refresh(key, func(ctx context.Context) (any, CachePolicy, error) {
+					value, err := fetch(ctx)
+					return value, policyOf(value), err
+				})
+			}
+			return cached, nil
+		}
+	} else if err != redis.Nil {
+		fmt.Printf("Redis error: %v\n", err)
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	if err := c.Store(ctx, key, value, policyOf(value).ttl()); err != nil {
+		fmt.Printf("Warning: could not save %s to cache: %v\n", key, err)
+	}
+	domain.RecordCacheMeta(ctx, domain.CacheMeta{
+		Key:         key,
+		Source:      "api",
+		FreshnessTS: time.Now().UTC().Format(time.RFC3339),
+	})
+	return value, nil
+}
+
+// refresh re-fetches key in the background. A local flag stops duplicate
+// goroutines in this process and a short Redis lock stops other replicas.
+func (c *SWRCache) refresh(key string, fetch func(ctx context.Context) (any, CachePolicy, error)) {
+	c.mu.Lock()
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		lock := "swr:lock:" + key
+		if ok, err := c.rdb.SetNX(ctx, lock, 1, 30*time.Second).Result(); err != nil || !ok {
+			return
+		}
+		defer c.rdb.Del(ctx, lock)
+
+		value, policy, err := fetch(ctx)
+		if err != nil {
+			fmt.Printf("background refresh of %s failed: %v\n", key, err)
+			return
+		}
+		if err := c.Store(ctx, key, value, policy.ttl()); err != nil {
+			fmt.Printf("Warning: could not save %s to cache: %v\n", key, err)
+		}
+	}()
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// teamAliasKey is a hash of "{league}:{canonical name}" to TeamAlias JSON.
+const teamAliasKey = "team:aliases"
+
+type teamAliasRepo struct {
+	rdb *redis.Client
+}
+
+func NewTeamAliasRepo(rdb *redis.Client) domain.ITeamAliasRepo {
+	return &teamAliasRepo{rdb: rdb}
+}
+
+func (r *teamAliasRepo) SaveTeamAlias(ctx context.Context, team domain.TeamAlias) error {
+	payload, err := json.Marshal(team)
+	if err != nil {
+		return domain.ErrInternalServer
+	}
+
+	field := fmt.Sprintf("%s:%s", team.League, team.Name)
+	if err := r.rdb.HSet(ctx, teamAliasKey, field, payload).Err(); err != nil {
+		return domain.ErrInternalServer
+	}
+	return nil
+}
+
+func (r *teamAliasRepo) AllTeamAliases(ctx context.Context) ([]domain.TeamAlias, error) {
+	vals, err := r.rdb.HGetAll(ctx, teamAliasKey).Result()
+	if err != nil {
+		return nil, domain.ErrInternalServer
+	}
+
+	teams := make([]domain.TeamAlias, 0, len(vals))
+	for field, raw := range vals {
+		var team domain.TeamAlias
+		if err := json.Unmarshal([]byte(raw), &team); err != nil {
+			fmt.Printf("skipping bad team alias %s: %v\n", field, err)
+			continue
+		}
+		teams = append(teams, team)
+	}
+	return teams, nil
+}
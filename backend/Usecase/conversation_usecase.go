@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// conversationTurns is how many recent turns a session keeps.
+const conversationTurns = 5
+
+type IConversationUsecase interface {
+	// Parse parses text within the session sessionID, filling the slots a
+	// follow-up leaves out from the previous turn. An empty or expired
+	// sessionID starts a new session.
+	Parse(ctx context.Context, sessionID, text string) (*domain.Conversation, *domain.Intent, error)
+	// ParseIn is Parse for callers with a stable session ID of their own,
+	// such as a chat: a new or expired session keeps sessionID.
+	ParseIn(ctx context.Context, sessionID, text string) (*domain.Conversation, *domain.Intent, error)
+	// Remember appends a turn to the session and renews its TTL.
+	Remember(ctx context.Context, conv *domain.Conversation, text string, intent *domain.Intent, answer *domain.Answer) error
+}
+
+type ConversationUsecase struct {
+	parser *ParseIntentUseCase
+	rules  *RuleIntentParser
+	repo   domain.IConversationRepo
+	ttl    time.Duration
+}
+
+func NewConversationUsecase(parser *ParseIntentUseCase, rules *RuleIntentParser, repo domain.IConversationRepo, ttl time.Duration) IConversationUsecase {
+	return &ConversationUsecase{parser: parser, rules: rules, repo: repo, ttl: ttl}
+}
+
+func (uc *ConversationUsecase) Parse(ctx context.Context, sessionID, text string) (*domain.Conversation, *domain.Intent, error) {
+	conv, err := uc.load(ctx, sessionID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return uc.parse(conv, text)
+}
+
+func (uc *ConversationUsecase) ParseIn(ctx context.Context, sessionID, text string) (*domain.Conversation, *domain.Intent, error) {
+	if sessionID == "" {
+		return nil, nil, ErrInvalidInput
+	}
+	conv, err := uc.load(ctx, sessionID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return uc.parse(conv, text)
+}
+
+func (uc *ConversationUsecase) parse(conv *domain.Conversation, text string) (*domain.Conversation, *domain.Intent, error) {
+
+	intent, err := uc.parser.Execute(text)
+	prev := conv.LastIntent()
+	if err != nil {
+		// "and tomorrow?" has no topic of its own but is fine as a follow-up
+		if prev == nil || !errors.Is(err, ErrIntentNotFound) {
+			return conv, nil, err
+		}
+		intent, _ = uc.rules.Match(text)
+	}
+
+	if prev != nil {
+		intent = mergeFollowUp(prev, intent, uc.rules.Mentions(text))
+	}
+	return conv, intent, nil
+}
+
+func (uc *ConversationUsecase) Remember(ctx context.Context, conv *domain.Conversation, text string, intent *domain.Intent, answer *domain.Answer) error {
+	turn := domain.ConversationTurn{Text: text, Intent: *intent, At: time.Now().UTC()}
+	if answer != nil {
+		turn.Answer = answer.Markdown
+	}
+
+	conv.Turns = append(conv.Turns, turn)
+	if len(conv.Turns) > conversationTurns {
+		conv.Turns = conv.Turns[len(conv.Turns)-conversationTurns:]
+	}
+	return uc.repo.SaveConversation(ctx, conv, uc.ttl)
+}
+
+// load returns the session sessionID, or a new one. A new session gets a
+// random ID unless keepID is set.
+func (uc *ConversationUsecase) load(ctx context.Context, sessionID string, keepID bool) (*domain.Conversation, error) {
+	if sessionID != "" {
+		conv, err := uc.repo.GetConversation(ctx, sessionID)
+		if err == nil {
+			return conv, nil
+		}
+		if !errors.Is(err, domain.ErrConversationNotFound) {
+			return nil, err
+		}
+	}
+
+	if keepID {
+		return &domain.Conversation{ID: sessionID, Turns: []domain.ConversationTurn{}}, nil
+	}
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Conversation{ID: id, Turns: []domain.ConversationTurn{}}, nil
+}
+
+// mergeFollowUp fills the slots the current query does not state with
+// those of the previous turn. stated holds what the text says outright, as
+// parsers fill in defaults (a league, a topic guessed from a lone team name)
+// that must not override the conversation so far.
+func mergeFollowUp(prev, cur, stated *domain.Intent) *domain.Intent {
+	merged := *cur
+	merged.Teams = slices.Clone(cur.Teams)
+
+	// "what about Arsenal?" keeps asking the previous question, unless the
+	// query names two teams and so reads as a comparison
+	if stated.Topic == "" && len(stated.Teams) < 2 && prev.Topic != "" {
+		merged.Topic = prev.Topic
+	}
+
+	switch {
+	case len(stated.Teams) == 0 && len(merged.Teams) == 0:
+		merged.Teams = slices.Clone(prev.Teams)
+	case len(stated.Teams) == 0 && len(cur.Teams) > 0:
+		// the parser found teams the rules do not know; trust it
+	case merged.Topic == "compare" && len(merged.Teams) == 1 && len(prev.Teams) > 0 && prev.Teams[0] != merged.Teams[0]:
+		// "compare Chelsea and Arsenal" then "and Liverpool?" compares Chelsea and Liverpool
+		merged.Teams = []string{prev.Teams[0], merged.Teams[0]}
+	}
+
+	// a new team brings its own league; otherwise stay in the previous one
+	if stated.League == "" && len(stated.Teams) == 0 && prev.League != "" {
+		merged.League = prev.League
+	}
+
+	if merged.Date == "" && merged.Topic == prev.Topic {
+		merged.Date = prev.Date
+	}
+	return &merged
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", domain.ErrInternalServer
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func newConversations(t *testing.T) (usecase.IConversationUsecase, *memoryConversations) {
+	t.Helper()
+	leagues, teams := loadConfig(t)
+	rules := usecase.NewRuleIntentParser(teams, leagues)
+	repo := &memoryConversations{sessions: map[string]domain.Conversation{}}
+	return usecase.NewConversationUsecase(usecase.NewParseIntentUsecase(rules), rules, repo, time.Hour), repo
+}
+
+func TestFollowUpsInheritTheirSlots(t *testing.T) {
+	tomorrow := time.Now().In(ethiotime.EAT).AddDate(0, 0, 1).Format("2006-01-02")
+
+	for _, tc := range []struct {
+		first, followUp string
+		want            domain.Intent
+	}{
+		{"When do Kedus Giorgis play tomorrow?", "what about fasil?",
+			domain.Intent{Topic: "fixture", Teams: []string{"Fasil Ketema"}, League: "ETH", Date: tomorrow}},
+		// the first team stays and the new one replaces the second
+		{"compare Arsenal and Chelsea", "and Liverpool?",
+			domain.Intent{Topic: "compare", Teams: []string{"Arsenal", "Liverpool"}, League: "EPL"}},
+		{"EPL table", "and tomorrow?",
+			domain.Intent{Topic: "table", Teams: []string{}, League: "EPL", Date: tomorrow}},
+		{"Arsenal news", "and the table?",
+			domain.Intent{Topic: "table", Teams: []string{"Arsenal"}, League: "EPL"}},
+		{"Kedus Giorgis fixtures", "what about Arsenal vs Chelsea",
+			domain.Intent{Topic: "compare", Teams: []string{"Arsenal", "Chelsea"}, League: "EPL"}},
+	} {
+		convs, _ := newConversations(t)
+		ctx := context.Background()
+
+		conv, first, err := convs.Parse(ctx, "", tc.first)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.first, err)
+		}
+		if err := convs.Remember(ctx, conv, tc.first, first, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		_, got, err := convs.Parse(ctx, conv.ID, tc.followUp)
+		if err != nil {
+			t.Fatalf("%q after %q: %v", tc.followUp, tc.first, err)
+		}
+		if got.Topic != tc.want.Topic || !slices.Equal(got.Teams, tc.want.Teams) || got.League != tc.want.League || got.Date != tc.want.Date {
+			t.Errorf("%q after %q = %+v, want %+v", tc.followUp, tc.first, *got, tc.want)
+		}
+	}
+}
+
+func TestConversationWithoutContext(t *testing.T) {
+	convs, repo := newConversations(t)
+	ctx := context.Background()
+
+	if _, _, err := convs.Parse(ctx, "", "and tomorrow?"); err != usecase.ErrIntentNotFound {
+		t.Errorf("follow-up without a session: err = %v, want ErrIntentNotFound", err)
+	}
+	if _, _, err := convs.ParseIn(ctx, "", "EPL table"); err != usecase.ErrInvalidInput {
+		t.Errorf("ParseIn without a session id: err = %v, want ErrInvalidInput", err)
+	}
+
+	conv, intent, err := convs.ParseIn(ctx, "tg:7", "EPL table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		if err := convs.Remember(ctx, conv, "EPL table", intent, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := len(repo.sessions["tg:7"].Turns); got != 5 {
+		t.Errorf("session keeps %d turns, want the last 5", got)
+	}
+}
+
+type memoryConversations struct {
+	sessions map[string]domain.Conversation
+}
+
+func (m *memoryConversations) GetConversation(ctx context.Context, id string) (*domain.Conversation, error) {
+	conv, ok := m.sessions[id]
+	if !ok {
+		return nil, domain.ErrConversationNotFound
+	}
+	conv.Turns = slices.Clone(conv.Turns)
+	return &conv, nil
+}
+
+func (m *memoryConversations) SaveConversation(ctx context.Context, conv *domain.Conversation, ttl time.Duration) error {
+	m.sessions[conv.ID] = *conv
+	return nil
+}
@@ -7,4 +7,5 @@ var (
 	ErrInvalidInput       = errors.New("invalid input")
 	ErrIntentNotFound     = errors.New("could not parse intent")
 	ErrServiceUnavailable = errors.New("intent service unavailable")
+	ErrUnsupportedTopic   = errors.New("unsupported topic")
 )
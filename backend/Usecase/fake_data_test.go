@@ -0,0 +1,187 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func loadConfig(t *testing.T) (domain.ILeagueRegistry, *usecase.TeamResolver) {
+	t.Helper()
+	leagues, err := infrastructure.LoadLeagueRegistry("../config/leagues.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, err := infrastructure.LoadTeamAliases("../config/team_aliases.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leagues, usecase.NewTeamResolver(nil, aliases)
+}
+
+func TestStandingsFromFakeData(t *testing.T) {
+	leagues, teams := loadConfig(t)
+	standings := usecase.NewStandingsUsecase(fake.NewStandingsRepo(fake.NewAPIService()), nil, leagues, teams)
+
+	table, err := standings.GetStandings(context.Background(), 363, 2022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Standings) == 0 {
+		t.Fatal("empty table")
+	}
+	for i, row := range table.Standings {
+		if row.Rank != i+1 {
+			t.Errorf("row %d has rank %d", i, row.Rank)
+		}
+		if row.Points != 3*row.Wins+row.Draws {
+			t.Errorf("%s: %d points from %d wins and %d draws", row.TeamName, row.Points, row.Wins, row.Draws)
+		}
+		if i > 0 && row.Points > table.Standings[i-1].Points {
+			t.Errorf("%s is ranked below a team with fewer points", row.TeamName)
+		}
+	}
+}
+
+func newNews(t *testing.T, events usecase.EventRepository, store domain.INewsRepo) *usecase.NewsUseCase {
+	t.Helper()
+	leagues, teams := loadConfig(t)
+	league, err := leagues.ByCode("ETH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates, err := infrastructure.LoadReportTemplates("../config/report_templates.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer, err := usecase.NewReportWriter(templates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return usecase.NewNewsUseCase(events, store, fake.NewStandingsRepo(fake.NewAPIService()), teams, writer, league)
+}
+
+func TestMatchReportsFromFakeEvents(t *testing.T) {
+	events := fake.NewEventRepository()
+	news := newNews(t, events, nil)
+
+	headlines, err := news.GenerateNews(ethiotime.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	past, _ := events.GetPastEvents()
+	if len(headlines) != len(past) {
+		t.Fatalf("%d headlines for %d results", len(headlines), len(past))
+	}
+	for i, e := range past {
+		for _, want := range []string{e.StrHomeTeam, e.StrAwayTeam, "Match Finished"} {
+			if !strings.Contains(headlines[i], want) {
+				t.Errorf("headline %q lacks %q", headlines[i], want)
+			}
+		}
+	}
+}
+
+func TestMatchReportsUseGoalDetails(t *testing.T) {
+	events := fake.NewEventRepository()
+	events.Past = []domain.Event{
+		{
+			IDEvent: "1", StrHomeTeam: "Saint George", StrAwayTeam: "Fasil Ketema",
+			IntHomeScore: "2", IntAwayScore: "1", DateEvent: "2023-03-04", StrTime: "13:00:00", StrStatus: "Match Finished",
+			StrHomeGoalDetails: "58':A. Player;90+3':B. Player;",
+			StrAwayGoalDetails: "45+1':C. Player;",
+		},
+		{
+			IDEvent: "2", StrHomeTeam: "Bahir Dar Kenema", StrAwayTeam: "Wolaitta Dicha",
+			DateEvent: "2023-03-05", StrTime: "13:00:00", StrStatus: "Match Postponed",
+		},
+	}
+
+	headlines, err := newNews(t, events, nil).GenerateNews(ethiotime.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headlines) != 1 {
+		t.Fatalf("headlines = %q, want the postponed match left out", headlines)
+	}
+	if !strings.Contains(headlines[0], "come from behind") {
+		t.Errorf("headline %q does not tell of the comeback from 0-1 at half time", headlines[0])
+	}
+}
+
+func TestFeedPublishesInTheBackground(t *testing.T) {
+	events := &countingEvents{EventRepository: fake.NewEventRepository()}
+	events.Err = errors.New("thesportsdb is down")
+	news := newNews(t, events, memoryNews{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := news.Feed(context.Background(), domain.NewsQuery{Limit: 20}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	deadline := time.Now().Add(time.Second)
+	for events.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := events.count(); n != 1 {
+		t.Fatalf("%d publishes for 10 concurrent reads, want 1", n)
+	}
+
+	// the failed publish is not retried on the next read
+	if _, err := news.Feed(context.Background(), domain.NewsQuery{Limit: 20}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := events.count(); n != 1 {
+		t.Errorf("%d publishes after a failure, want the retry held back", n)
+	}
+}
+
+// countingEvents counts the reads of past events, one per Publish.
+type countingEvents struct {
+	*fake.EventRepository
+	mu    sync.Mutex
+	calls int
+}
+
+func (e *countingEvents) GetPastEvents() ([]domain.Event, error) {
+	e.mu.Lock()
+	e.calls++
+	e.mu.Unlock()
+	return e.EventRepository.GetPastEvents()
+}
+
+func (e *countingEvents) count() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+type memoryNews struct{}
+
+func (memoryNews) SaveNews(ctx context.Context, item domain.NewsItem) error { return nil }
+
+func (memoryNews) GetNews(ctx context.Context, id string) (*domain.NewsItem, error) {
+	return nil, domain.ErrNewsNotFound
+}
+
+func (memoryNews) ListNews(ctx context.Context, q domain.NewsQuery) ([]domain.NewsItem, error) {
+	return nil, nil
+}
@@ -3,35 +3,50 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 	repository "github.com/abrshodin/ethio-fb-backend/Repository"
 )
 
 type IFixturesUsecase interface {
-	FetchAndStore(ctx context.Context, league string, leagueID int, q domain.RoundQuery) (*[]domain.PrevFixtures, error)
+	FetchAndStore(ctx context.Context, league string, q domain.RoundQuery) (*[]domain.PrevFixtures, error)
 	GetCachedByRound(ctx context.Context, q domain.RoundQuery) (*[]domain.PrevFixtures, error)
 	ResolveRoundWindow(ctx context.Context, q domain.RoundQuery) (domain.RoundQuery, error)
-	GetLiveMatches (league string)(*[]domain.PrevFixtures, error)
-	GetFixtures(ctx context.Context, league, team, season, from, to string) ([]domain.Fixture, error)
+	GetLiveMatches(ctx context.Context, league string) (*[]domain.PrevFixtures, error)
+	PollLive(ctx context.Context, league string) error
+	MatchDetail(ctx context.Context, fixtureID int) (*domain.MatchDetail, error)
+	SeasonResults(ctx context.Context, league string, season int) ([]domain.PrevFixtures, error)
 }
 
-func NewFixturesUsecase(api domain.IAPIService, repo repository.IFixturesRepo) IFixturesUsecase {
-	return &FixturesUsecase{api: api, repo: repo}
+func NewFixturesUsecase(api domain.IAPIService, provider domain.FootballDataProvider, repo repository.IFixturesRepo, cache *repository.SWRCache, calendar ISeasonCalendar, schedule domain.IJobRepo) IFixturesUsecase {
+	return &FixturesUsecase{api: api, provider: provider, repo: repo, cache: cache, calendar: calendar, schedule: schedule, now: time.Now}
 }
 
 type FixturesUsecase struct {
-	api  domain.IAPIService
-	repo repository.IFixturesRepo
+	api      domain.IAPIService
+	provider domain.FootballDataProvider
+	repo     repository.IFixturesRepo
+	cache    *repository.SWRCache
+	calendar ISeasonCalendar
+	schedule domain.IJobRepo
+	now      func() time.Time
 }
 
-func (uc *FixturesUsecase) FetchAndStore(ctx context.Context, league string, leagueID int, q domain.RoundQuery) (*[]domain.PrevFixtures, error) {
+func (uc *FixturesUsecase) FetchAndStore(ctx context.Context, league string, q domain.RoundQuery) (*[]domain.PrevFixtures, error) {
 
-	fixtures, err := uc.api.PrevFixtures(leagueID, q.Season, q.From, q.To)
-	if err != nil {
+	list, err := uc.provider.Fixtures(ctx, league, q.Season, q.From, q.To)
+	if err != nil && !errors.Is(err, domain.ErrNoData) {
 		return nil, err
 	}
+	fixtures := &list
+	domain.RecordCacheMeta(ctx, domain.CacheMeta{
+		Key:         fmt.Sprintf("fixtures:%s:%d:%s", league, q.Season, q.Round),
+		Source:      "api",
+		FreshnessTS: time.Now().UTC().Format(time.RFC3339),
+	})
 
 	// group by round and store
 	rounds := map[string][]domain.PrevFixtures{}
@@ -48,10 +63,11 @@ func (uc *FixturesUsecase) FetchAndStore(ctx context.Context, league string, lea
 		}
 	}
 
-	// stores round with from, to dates
-	for round := range rounds {
+	// stores round with the dates of its first and last match
+	for round, w := range roundWindows(*fixtures) {
 		rq := q
 		rq.Round = round
+		rq.From, rq.To = w.From, w.To
 		if err := uc.repo.SaveRoundWindow(ctx, rq); err != nil {
 			return nil, err
 		}
@@ -88,53 +104,115 @@ func (uc *FixturesUsecase) ResolveRoundWindow(ctx context.Context, q domain.Roun
 		return q, nil
 	}
 
-	if from, to, err := uc.repo.GetRoundWindow(ctx, q); err == nil && from != "" && to != "" {
-		q.From, q.To = from, to
-		return q, nil
-	}
+	return uc.calendar.RoundWindow(ctx, q.League, q.Season, q.Round)
+}
 
-	seasonWindows := map[string]map[int]struct{ From, To string }{
-		"ETH": {
-			2021: {From: "2021-10-17", To: "2022-06-28"},
-			2022: {From: "2022-09-30", To: "2023-07-08"},
-			2023: {From: "2023-10-01", To: "2024-06-30"},
-		},
-
-		"EPL": {
-			2021: {From: "2021-08-24", To: "2022-04-03"},
-			2022: {From: "2022-08-30", To: "2023-04-02"},
-			2023: {From: "2023-08-22", To: "2024-04-07"},
-		},
+// liveSnapshotTTL is how long a live snapshot polled by the worker is
+// served before requests go upstream again.
+const liveSnapshotTTL = 2 * time.Minute
+
+func liveKey(league string) string { return fmt.Sprintf("live:%s", league) }
+
+// GetLiveMatches serves the worker's latest live snapshot of the league.
+// Without one it answers with no matches when the worker's kickoff times
+// say none is in play, and asks upstream otherwise; either answer is kept
+// as the snapshot for the next requests.
+func (uc *FixturesUsecase) GetLiveMatches(ctx context.Context, league string) (*[]domain.PrevFixtures, error) {
+	var live []domain.PrevFixtures
+	if _, err := uc.cache.Load(ctx, liveKey(league), &live); err == nil {
+		return &live, nil
 	}
 
-	if seasons, ok := seasonWindows[q.League]; ok {
-		if win, ok := seasons[q.Season]; ok {
-			q.From, q.To = win.From, win.To
-			_ = uc.repo.SaveRoundWindow(ctx, q)
-			return q, nil
+	live = []domain.PrevFixtures{}
+	kickoffs, err := uc.schedule.Kickoffs(ctx, league)
+	if err != nil || kickoffs == nil || inKickoffWindow(kickoffs, uc.now()) {
+		fixtures, err := uc.api.LiveFixtures(ctx, league)
+		if err != nil {
+			return nil, err
+		}
+		if fixtures != nil {
+			live = *fixtures
 		}
 	}
 
-	return q, errors.New("round window not found")
+	if err := uc.cache.Store(ctx, liveKey(league), live, liveSnapshotTTL); err != nil {
+		fmt.Printf("could not store live snapshot (league=%s): %v\n", league, err)
+	}
+	return &live, nil
 }
 
-func(uc *FixturesUsecase) GetLiveMatches (league string)(*[]domain.PrevFixtures, error){
-	return uc.api.LiveFixtures(league)
-}
+// PollLive fetches the live matches of the league upstream and stores them
+// for GetLiveMatches.
+func (uc *FixturesUsecase) PollLive(ctx context.Context, league string) error {
+	fixtures, err := uc.api.LiveFixtures(ctx, league)
+	if err != nil {
+		return err
+	}
 
-func (uc *FixturesUsecase) GetFixtures(ctx context.Context, league, team, season, from, to string) ([]domain.Fixture, error) {
-	if league == "" {
-		return nil, errors.New("league is required")
+	live := []domain.PrevFixtures{}
+	if fixtures != nil {
+		live = *fixtures
 	}
+	return uc.cache.Store(ctx, liveKey(league), live, liveSnapshotTTL)
+}
 
-	fixtures, err := uc.repo.GetFixtures(league, team, season, from, to)
-	if err != nil {
-		return nil, err
+// MatchDetail returns the timeline, lineups and statistics of a fixture.
+func (uc *FixturesUsecase) MatchDetail(ctx context.Context, fixtureID int) (*domain.MatchDetail, error) {
+	key := fmt.Sprintf("match:%d", fixtureID)
+	return repository.FetchByValue(ctx, uc.cache, key, uc.matchDetailPolicy, func(ctx context.Context) (*domain.MatchDetail, error) {
+		return uc.api.FixtureDetail(ctx, fixtureID)
+	})
+}
+
+// matchSettleTime is how long after kickoff api-sports may still be filling
+// in the events and statistics of a match that is over.
+const matchSettleTime = 6 * time.Hour
+
+// matchDetailPolicy keeps a match that is over for good once it has
+// settled, refreshes one in play every half minute and one yet to start
+// every quarter hour, when lineups may have been announced.
+func (uc *FixturesUsecase) matchDetailPolicy(d *domain.MatchDetail) repository.CachePolicy {
+	if d == nil {
+		return repository.CachePolicy{FreshFor: time.Minute, StaleFor: time.Minute}
 	}
-	
-	if fixtures == nil {
-		return []domain.Fixture{}, nil
+
+	switch short := d.Status.Short; {
+	case finishedStatuses[short], short == "CANC", short == "ABD", short == "AWD", short == "WO":
+		kickoff, err := time.Parse(time.RFC3339, d.Date)
+		if settled := kickoff.Add(matchSettleTime); err == nil && uc.now().Before(settled) {
+			// expire when the match settles so the final version is fetched
+			// once more and kept
+			return repository.CachePolicy{FreshFor: 10 * time.Minute, StaleFor: settled.Sub(uc.now())}
+		}
+		return repository.CachePolicy{}
+	case pendingStatuses[short], short == "PST":
+		return repository.CachePolicy{FreshFor: 15 * time.Minute, StaleFor: 7 * 24 * time.Hour}
+	default:
+		return repository.CachePolicy{FreshFor: 30 * time.Second, StaleFor: 10 * time.Minute}
 	}
-	return fixtures, nil
 }
 
+// seasonResultsPolicy refreshes a season's results hourly; a finished
+// season simply refetches the same list.
+var seasonResultsPolicy = repository.CachePolicy{FreshFor: time.Hour, StaleFor: 30 * 24 * time.Hour}
+
+// SeasonResults returns every fixture of a league season. Each fetch also
+// stores the fixtures by round, as FetchAndStore does.
+func (uc *FixturesUsecase) SeasonResults(ctx context.Context, league string, season int) ([]domain.PrevFixtures, error) {
+	key := fmt.Sprintf("results:%s:%d", league, season)
+	return repository.Fetch(ctx, uc.cache, key, seasonResultsPolicy, func(ctx context.Context) ([]domain.PrevFixtures, error) {
+		q, err := uc.calendar.SeasonWindow(ctx, league, season)
+		if err != nil {
+			return nil, err
+		}
+		fixtures, err := uc.FetchAndStore(ctx, q.League, q)
+		if err != nil {
+			return nil, err
+		}
+		if len(*fixtures) == 0 {
+			// not cached, so the next read asks upstream again
+			return nil, domain.ErrNoData
+		}
+		return *fixtures, nil
+	})
+}
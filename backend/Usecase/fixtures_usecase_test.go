@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	repository "github.com/abrshodin/ethio-fb-backend/Repository"
+)
+
+func detail(id int, short string) *domain.MatchDetail {
+	return &domain.MatchDetail{PrevFixtures: domain.PrevFixtures{
+		FixtureID: id,
+		Date:      kickoff.Format(time.RFC3339),
+		Status:    domain.Status{Short: short},
+	}}
+}
+
+func TestMatchDetailPolicy(t *testing.T) {
+	uc := &FixturesUsecase{}
+	for _, tc := range []struct {
+		short string
+		at    time.Time
+		want  repository.CachePolicy
+	}{
+		{"NS", kickoff.Add(-time.Hour), repository.CachePolicy{FreshFor: 15 * time.Minute, StaleFor: 7 * 24 * time.Hour}},
+		{"PST", kickoff.Add(time.Hour), repository.CachePolicy{FreshFor: 15 * time.Minute, StaleFor: 7 * 24 * time.Hour}},
+		{"2H", kickoff.Add(time.Hour), repository.CachePolicy{FreshFor: 30 * time.Second, StaleFor: 10 * time.Minute}},
+		{"SUSP", kickoff.Add(time.Hour), repository.CachePolicy{FreshFor: 30 * time.Second, StaleFor: 10 * time.Minute}},
+		// over, but events may still be filled in until it settles
+		{"FT", kickoff.Add(2 * time.Hour), repository.CachePolicy{FreshFor: 10 * time.Minute, StaleFor: matchSettleTime - 2*time.Hour}},
+		{"FT", kickoff.Add(matchSettleTime), repository.CachePolicy{}},
+		{"AWD", kickoff.Add(24 * time.Hour), repository.CachePolicy{}},
+		{"CANC", kickoff.Add(24 * time.Hour), repository.CachePolicy{}},
+	} {
+		uc.now = func() time.Time { return tc.at }
+		if got := uc.matchDetailPolicy(detail(1, tc.short)); got != tc.want {
+			t.Errorf("%s at kickoff%+v: policy = %+v, want %+v", tc.short, tc.at.Sub(kickoff), got, tc.want)
+		}
+	}
+}
+
+func TestMatchDetailKeepsSettledMatches(t *testing.T) {
+	api := fake.NewAPIService()
+	api.Details = map[int]*domain.MatchDetail{1: detail(1, "FT"), 2: detail(2, "2H")}
+	rdb, store := fake.NewRedis()
+	uc := &FixturesUsecase{api: api, cache: repository.NewSWRCache(rdb), now: func() time.Time { return kickoff.Add(24 * time.Hour) }}
+	ctx := context.Background()
+
+	for _, id := range []int{1, 2} {
+		if _, err := uc.MatchDetail(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := uc.MatchDetail(ctx, 3); !errors.Is(err, domain.ErrFixtureNotFound) {
+		t.Errorf("unknown fixture: err = %v, want ErrFixtureNotFound", err)
+	}
+
+	store.Age(11 * time.Minute)
+	if keys := store.Keys("match:"); len(keys) != 1 || keys[0] != "match:1" {
+		t.Errorf("cached after 11 minutes: %v, want only the settled match", keys)
+	}
+
+	// the settled match is served from the cache even with upstream down
+	api.Err = errors.New("api-sports is down")
+	if d, err := uc.MatchDetail(ctx, 1); err != nil || d.Status.Short != "FT" {
+		t.Errorf("settled match = %+v, %v, want it from the cache", d, err)
+	}
+}
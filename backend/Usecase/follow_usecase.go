@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// feedLimit caps the upcoming and result lists of a feed.
+const feedLimit = 10
+
+type IFollowUsecase interface {
+	Follow(ctx context.Context, userID string, teamID int, notify bool) (*domain.FollowedTeam, error)
+	Unfollow(ctx context.Context, userID string, teamID int) error
+	List(ctx context.Context, userID string) ([]domain.FollowedTeam, error)
+	Feed(ctx context.Context, userID string) (*domain.Feed, error)
+}
+
+type FollowUsecase struct {
+	follows   domain.IFollowRepo
+	teams     TeamUsecases
+	fixtures  FixtureUsecase
+	standings IStandingsUsecase
+	seasons   ISeasonCalendar
+	leagues   domain.ILeagueRegistry
+}
+
+func NewFollowUsecase(
+	follows domain.IFollowRepo,
+	teams TeamUsecases,
+	fixtures FixtureUsecase,
+	standings IStandingsUsecase,
+	seasons ISeasonCalendar,
+	leagues domain.ILeagueRegistry,
+) IFollowUsecase {
+	return &FollowUsecase{
+		follows:   follows,
+		teams:     teams,
+		fixtures:  fixtures,
+		standings: standings,
+		seasons:   seasons,
+		leagues:   leagues,
+	}
+}
+
+func (uc *FollowUsecase) Follow(ctx context.Context, userID string, teamID int, notify bool) (*domain.FollowedTeam, error) {
+	if userID == "" {
+		return nil, ErrInvalidInput
+	}
+	if _, err := uc.teams.GetTeamByID(ctx, teamID); err != nil {
+		return nil, err
+	}
+
+	follow := domain.FollowedTeam{
+		TeamID:    strconv.Itoa(teamID),
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Notify:    notify,
+	}
+	if err := uc.follows.Follow(ctx, userID, follow); err != nil {
+		return nil, err
+	}
+	return &follow, nil
+}
+
+func (uc *FollowUsecase) Unfollow(ctx context.Context, userID string, teamID int) error {
+	if userID == "" {
+		return ErrInvalidInput
+	}
+	return uc.follows.Unfollow(ctx, userID, strconv.Itoa(teamID))
+}
+
+func (uc *FollowUsecase) List(ctx context.Context, userID string) ([]domain.FollowedTeam, error) {
+	if userID == "" {
+		return nil, ErrInvalidInput
+	}
+	return uc.follows.List(ctx, userID)
+}
+
+// Feed merges the fixtures, results and table rows of every followed team.
+// A team whose data cannot be fetched is skipped rather than failing the feed.
+func (uc *FollowUsecase) Feed(ctx context.Context, userID string) (*domain.Feed, error) {
+	follows, err := uc.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.Feed{
+		Teams:       []domain.Team{},
+		Upcoming:    []domain.Fixture{},
+		Results:     []domain.Fixture{},
+		CalledOff:   []domain.Fixture{},
+		Standings:   []domain.FeedStanding{},
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	seen := map[string]bool{}
+	standingsByLeague := map[string]*domain.StandingsResponse{}
+
+	for _, follow := range follows {
+		teamID, err := strconv.Atoi(follow.TeamID)
+		if err != nil {
+			continue
+		}
+		team, err := uc.teams.GetTeamByID(ctx, teamID)
+		if err != nil {
+			fmt.Printf("feed: team %s not found: %v\n", follow.TeamID, err)
+			continue
+		}
+		feed.Teams = append(feed.Teams, *team)
+
+		league, ok := uc.leagueOf(team)
+		if !ok {
+			continue
+		}
+		season, err := uc.seasons.CurrentSeason(ctx, league.Code)
+		if err != nil {
+			fmt.Printf("feed: no season for %s: %v\n", league.Code, err)
+			continue
+		}
+
+		fixtures, err := uc.fixtures.GetFixtures(ctx, league.Code, follow.TeamID, strconv.Itoa(season), "", "")
+		if err != nil {
+			fmt.Printf("feed: fixtures for team %s failed: %v\n", follow.TeamID, err)
+		}
+		for _, f := range fixtures {
+			if seen[f.ID] {
+				continue
+			}
+			seen[f.ID] = true
+			switch f.Status {
+			case "finished":
+				feed.Results = append(feed.Results, f)
+			case "postponed", "cancelled":
+				feed.CalledOff = append(feed.CalledOff, f)
+			default:
+				feed.Upcoming = append(feed.Upcoming, f)
+			}
+		}
+
+		key := fmt.Sprintf("%s:%d", league.Code, season)
+		table, ok := standingsByLeague[key]
+		if !ok {
+			table, err = uc.standings.GetStandings(ctx, league.APISportsID, season)
+			if err != nil {
+				fmt.Printf("feed: standings for %s failed: %v\n", key, err)
+			}
+			standingsByLeague[key] = table
+		}
+		if table != nil {
+			for _, row := range table.Standings {
+				if row.TeamName == team.Name {
+					feed.Standings = append(feed.Standings, domain.FeedStanding{League: league.Code, Season: season, Standing: row})
+					break
+				}
+			}
+		}
+	}
+
+	sort.Slice(feed.Upcoming, func(i, j int) bool { return feed.Upcoming[i].DateUTC < feed.Upcoming[j].DateUTC })
+	sort.Slice(feed.Results, func(i, j int) bool { return feed.Results[i].DateUTC > feed.Results[j].DateUTC })
+	sort.Slice(feed.CalledOff, func(i, j int) bool { return feed.CalledOff[i].DateUTC < feed.CalledOff[j].DateUTC })
+	if len(feed.Upcoming) > feedLimit {
+		feed.Upcoming = feed.Upcoming[:feedLimit]
+	}
+	if len(feed.Results) > feedLimit {
+		feed.Results = feed.Results[:feedLimit]
+	}
+	if len(feed.CalledOff) > feedLimit {
+		feed.CalledOff = feed.CalledOff[:feedLimit]
+	}
+
+	return feed, nil
+}
+
+// leagueOf finds the registry entry for a cached team, which stores its
+// league by English display name.
+func (uc *FollowUsecase) leagueOf(team *domain.Team) (*domain.LeagueConfig, bool) {
+	for _, l := range uc.leagues.All() {
+		if l.Name("en") == team.League || l.Code == team.League {
+			l := l
+			return &l, true
+		}
+	}
+	return nil, false
+}
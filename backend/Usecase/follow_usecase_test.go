@@ -0,0 +1,91 @@
+package usecase_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func TestFeedSplitsCalledOffMatches(t *testing.T) {
+	leagues, _ := loadConfig(t)
+	follows := followsOf{"u1": {{TeamID: "7"}}}
+	fixtures := teamFixtures{
+		{ID: "1", DateUTC: "2024-03-02T13:00:00Z", Status: "finished"},
+		{ID: "2", DateUTC: "2024-03-05T13:00:00Z", Status: "finished"}, // awarded
+		{ID: "3", DateUTC: "2024-03-09T13:00:00Z", Status: "postponed"},
+		{ID: "4", DateUTC: "2024-03-12T13:00:00Z", Status: "cancelled"},
+		{ID: "5", DateUTC: "2024-03-16T13:00:00Z", Status: "suspended"},
+		{ID: "6", DateUTC: "2024-03-23T13:00:00Z", Status: "scheduled"},
+	}
+	uc := usecase.NewFollowUsecase(follows, oneTeam{}, fixtures, noTable{}, seasonOf{season: 2023}, leagues)
+
+	feed, err := uc.Feed(context.Background(), "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tc := range map[string]struct {
+		got  []domain.Fixture
+		want []string
+	}{
+		"results":    {feed.Results, []string{"2", "1"}},
+		"called off": {feed.CalledOff, []string{"3", "4"}},
+		"upcoming":   {feed.Upcoming, []string{"5", "6"}},
+	} {
+		ids := []string{}
+		for _, f := range tc.got {
+			ids = append(ids, f.ID)
+		}
+		if !slices.Equal(ids, tc.want) {
+			t.Errorf("%s = %v, want %v", name, ids, tc.want)
+		}
+	}
+}
+
+type followsOf map[string][]domain.FollowedTeam
+
+func (f followsOf) Follow(ctx context.Context, userID string, follow domain.FollowedTeam) error {
+	f[userID] = append(f[userID], follow)
+	return nil
+}
+
+func (f followsOf) Unfollow(ctx context.Context, userID, teamID string) error { return nil }
+
+func (f followsOf) List(ctx context.Context, userID string) ([]domain.FollowedTeam, error) {
+	return f[userID], nil
+}
+
+func (f followsOf) Get(ctx context.Context, userID, teamID string) (*domain.FollowedTeam, error) {
+	return nil, domain.ErrFollowNotFound
+}
+
+func (f followsOf) Followers(ctx context.Context, teamID string) ([]string, error) { return nil, nil }
+
+type oneTeam struct{ usecase.TeamUsecases }
+
+func (oneTeam) GetTeamByID(ctx context.Context, teamID int) (*domain.Team, error) {
+	return &domain.Team{ID: "7", Name: "Saint George", League: "Ethiopian Premier League"}, nil
+}
+
+type teamFixtures []domain.Fixture
+
+func (f teamFixtures) GetFixtures(ctx context.Context, league, team, season, from, to string) ([]domain.Fixture, error) {
+	return f, nil
+}
+
+type noTable struct{ usecase.IStandingsUsecase }
+
+func (noTable) GetStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	return &domain.StandingsResponse{}, nil
+}
+
+type seasonOf struct {
+	usecase.ISeasonCalendar
+	season int
+}
+
+func (s seasonOf) CurrentSeason(ctx context.Context, league string) (int, error) {
+	return s.season, nil
+}
@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	repository "github.com/abrshodin/ethio-fb-backend/Repository"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func meeting(id int, date string, home, away int, short string, goals ...int) domain.PrevFixtures {
+	f := domain.PrevFixtures{
+		FixtureID: id,
+		Date:      date + "T13:00:00+00:00",
+		HomeTeam:  domain.MTeam{ID: home},
+		AwayTeam:  domain.MTeam{ID: away},
+		Status:    domain.Status{Short: short},
+	}
+	if len(goals) == 2 {
+		f.Goals = domain.Goals{Home: &goals[0], Away: &goals[1]}
+	}
+	return f
+}
+
+func TestHeadToHeadIsCountedFromEitherSide(t *testing.T) {
+	leagues, teams := loadConfig(t)
+	api := &h2hCounter{APIService: fake.NewAPIService()}
+	api.Past = []domain.PrevFixtures{
+		meeting(1, "2023-10-07", 10, 20, "FT", 2, 1),
+		meeting(2, "2023-12-02", 20, 10, "FT", 0, 0),
+		meeting(3, "2024-02-10", 20, 10, "PEN", 1, 1), // a shoot-out is a draw
+		meeting(4, "2024-03-09", 10, 30, "FT", 3, 0),  // another opponent
+		meeting(5, "2024-04-06", 10, 20, "PST"),       // never played
+	}
+	rdb, store := fake.NewRedis()
+	teamsUC := usecase.NewTeamUsecase(nil, repository.NewSWRCache(rdb), teams, api, leagues)
+	ctx := context.Background()
+
+	h2h, err := teamsUC.HeadToHead(ctx, 10, 20, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h2h.Played != 3 || h2h.WinsA != 1 || h2h.WinsB != 0 || h2h.Draws != 2 || h2h.GoalsA != 3 || h2h.GoalsB != 2 {
+		t.Errorf("10 v 20 = %+v, want P3 W1 D2 L0, 3-2 on goals", h2h)
+	}
+	if len(h2h.Meetings) != 3 || h2h.Meetings[0].FixtureID != 3 || h2h.Meetings[2].FixtureID != 1 {
+		t.Errorf("meetings = %+v, want the finished ones latest first", h2h.Meetings)
+	}
+
+	reverse, err := teamsUC.HeadToHead(ctx, 20, 10, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reverse.TeamA.ID != 20 || reverse.WinsA != 0 || reverse.WinsB != 1 || reverse.GoalsA != 2 || reverse.GoalsB != 3 {
+		t.Errorf("20 v 10 = %+v, want the same record from the other side", reverse)
+	}
+	if api.calls != 1 {
+		t.Errorf("meetings fetched %d times, want one cache entry for both orders", api.calls)
+	}
+	if keys := store.Keys("h2h:"); len(keys) != 1 || keys[0] != "h2h:10-20:10" {
+		t.Errorf("cache keys = %v, want h2h:10-20:10", keys)
+	}
+
+	if _, err := teamsUC.HeadToHead(ctx, 10, 10, 10); err != usecase.ErrInvalidInput {
+		t.Errorf("a team against itself: err = %v, want ErrInvalidInput", err)
+	}
+}
+
+// h2hCounter counts the head-to-head fetches of the fake API.
+type h2hCounter struct {
+	*fake.APIService
+	calls int
+}
+
+func (a *h2hCounter) HeadToHead(ctx context.Context, teamA, teamB, last int) (*[]domain.PrevFixtures, error) {
+	a.calls++
+	return a.APIService.HeadToHead(ctx, teamA, teamB, last)
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const (
+	fixturesJobInterval  = 6 * time.Hour
+	standingsJobInterval = 30 * time.Minute
+	teamsJobInterval     = 24 * time.Hour
+
+	// prefetchDays is how far ahead fixtures are fetched.
+	prefetchDays = 7
+	// A match is taken to be in play from kickoff until matchLength later,
+	// stoppages and half time included; live polling starts liveLead early
+	// for kickoffs that are brought forward.
+	matchLength = 2*time.Hour + 15*time.Minute
+	liveLead    = 10 * time.Minute
+
+	// jobRetryDelay is the wait before retrying a failed run; it doubles
+	// with each failure in a row, up to the job's interval.
+	jobRetryDelay = time.Minute
+)
+
+// Job is a task the ingestion worker runs every interval.
+type Job struct {
+	Name  string
+	Every time.Duration
+	Run   func(ctx context.Context) error
+}
+
+// IngestionWorker fetches data ahead of requests: the coming week's
+// fixtures, the tables once matches have ended, the team lists, and live
+// scores while matches are being played. Each run of a job is leased in
+// Redis, so replicas running the worker side by side share the work.
+type IngestionWorker struct {
+	jobs      domain.IJobRepo
+	leagues   domain.ILeagueRegistry
+	seasons   ISeasonCalendar
+	fixtures  FixtureUsecase
+	live      IFixturesUsecase
+	standings domain.IStandingsRepo
+	teams     TeamUsecases
+	livePoll  time.Duration
+	now       func() time.Time
+}
+
+func NewIngestionWorker(
+	jobs domain.IJobRepo,
+	leagues domain.ILeagueRegistry,
+	seasons ISeasonCalendar,
+	fixtures FixtureUsecase,
+	live IFixturesUsecase,
+	standings domain.IStandingsRepo,
+	teams TeamUsecases,
+	livePoll time.Duration,
+) *IngestionWorker {
+	return &IngestionWorker{
+		jobs:      jobs,
+		leagues:   leagues,
+		seasons:   seasons,
+		fixtures:  fixtures,
+		live:      live,
+		standings: standings,
+		teams:     teams,
+		livePoll:  livePoll,
+		now:       time.Now,
+	}
+}
+
+// Jobs lists the worker's jobs. The standings and live jobs act on the
+// kickoff times the fixtures job saves.
+func (w *IngestionWorker) Jobs() []Job {
+	return []Job{
+		{Name: "fixtures", Every: fixturesJobInterval, Run: w.PrefetchFixtures},
+		{Name: "standings", Every: standingsJobInterval, Run: w.RefreshStandings},
+		{Name: "teams", Every: teamsJobInterval, Run: w.WarmTeams},
+		{Name: "live", Every: w.livePoll, Run: w.PollLive},
+	}
+}
+
+// Run runs every job on its interval, starting now, and blocks until ctx
+// is cancelled. Failed runs are retried sooner, with backoff.
+func (w *IngestionWorker) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range w.Jobs() {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+
+			failures := 0
+			for {
+				wait := job.Every
+				if err := w.runOnce(ctx, job); err != nil {
+					fmt.Printf("worker: %s failed: %v\n", job.Name, err)
+					failures++
+					wait = retryDelay(job.Every, failures)
+				} else {
+					failures = 0
+				}
+
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			}
+		}(job)
+	}
+	wg.Wait()
+}
+
+// runOnce runs job unless another replica holds the lease of this run.
+// The lease of a successful run is not given back, so it also spaces runs
+// across replicas; that of a failed run is, so the run can be retried.
+func (w *IngestionWorker) runOnce(ctx context.Context, job Job) error {
+	claimed, err := w.jobs.Claim(ctx, job.Name, job.Every-job.Every/10)
+	if err != nil {
+		return fmt.Errorf("claim: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	started := w.now()
+	runCtx, cancel := context.WithTimeout(ctx, job.Every)
+	defer cancel()
+	if err := job.Run(runCtx); err != nil {
+		if rerr := w.jobs.Release(ctx, job.Name); rerr != nil {
+			fmt.Printf("worker: could not release %s: %v\n", job.Name, rerr)
+		}
+		return err
+	}
+	if err := w.jobs.SetLastRun(ctx, job.Name, started); err != nil {
+		fmt.Printf("worker: could not record %s run: %v\n", job.Name, err)
+	}
+	return nil
+}
+
+// retryDelay is the wait before the next try of a job that failed
+// failures times in a row.
+func retryDelay(every time.Duration, failures int) time.Duration {
+	delay := jobRetryDelay
+	for i := 1; i < failures && delay < every; i++ {
+		delay *= 2
+	}
+	return min(delay, every)
+}
+
+// PrefetchFixtures fetches each league's fixtures from yesterday to a week
+// ahead, which warms the /fixtures cache, and saves their kickoff times.
+func (w *IngestionWorker) PrefetchFixtures(ctx context.Context) error {
+	today := w.now().UTC()
+	from := today.AddDate(0, 0, -1).Format("2006-01-02")
+	to := today.AddDate(0, 0, prefetchDays).Format("2006-01-02")
+
+	var errs []error
+	for _, league := range w.leagues.All() {
+		season, err := w.seasons.CurrentSeason(ctx, league.Code)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s season: %w", league.Code, err))
+			continue
+		}
+		fixtures, err := w.fixtures.GetFixtures(ctx, league.Code, "", strconv.Itoa(season), from, to)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s fixtures: %w", league.Code, err))
+			continue
+		}
+
+		// an empty list, unlike none, tells readers no match is coming up
+		kickoffs := []time.Time{}
+		for _, f := range fixtures {
+			if f.Status == "postponed" || f.Status == "cancelled" {
+				continue
+			}
+			if t, err := time.Parse(time.RFC3339, f.DateUTC); err == nil {
+				kickoffs = append(kickoffs, t)
+			}
+		}
+		if err := w.jobs.SaveKickoffs(ctx, league.Code, kickoffs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RefreshStandings fetches the table of each league where a match has
+// ended since the last run; the first run refreshes every league.
+func (w *IngestionWorker) RefreshStandings(ctx context.Context) error {
+	last, err := w.jobs.LastRun(ctx, "standings")
+	if err != nil {
+		return err
+	}
+	now := w.now()
+
+	var errs []error
+	for _, league := range w.leagues.All() {
+		kickoffs, err := w.jobs.Kickoffs(ctx, league.Code)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ended := last.IsZero()
+		for _, k := range kickoffs {
+			if end := k.Add(matchLength); end.After(last) && !end.After(now) {
+				ended = true
+			}
+		}
+		if !ended {
+			continue
+		}
+
+		season, err := w.seasons.CurrentSeason(ctx, league.Code)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s season: %w", league.Code, err))
+			continue
+		}
+		if _, err := w.standings.RefreshStandings(ctx, league.APISportsID, season); err != nil {
+			errs = append(errs, fmt.Errorf("%s standings: %w", league.Code, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WarmTeams caches the team list of each league for its current season,
+// which also registers the teams' ids with the name resolver.
+func (w *IngestionWorker) WarmTeams(ctx context.Context) error {
+	var errs []error
+	for _, league := range w.leagues.All() {
+		season, err := w.seasons.CurrentSeason(ctx, league.Code)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s season: %w", league.Code, err))
+			continue
+		}
+		if err := w.teams.FetchAndCacheTeams(ctx, league.APISportsID, season); err != nil {
+			errs = append(errs, fmt.Errorf("%s teams: %w", league.Code, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PollLive polls the live matches of each league with a match in play.
+// Outside those kickoff windows it costs no upstream calls.
+func (w *IngestionWorker) PollLive(ctx context.Context) error {
+	now := w.now()
+
+	var errs []error
+	for _, league := range w.leagues.All() {
+		kickoffs, err := w.jobs.Kickoffs(ctx, league.Code)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !inKickoffWindow(kickoffs, now) {
+			continue
+		}
+		if err := w.live.PollLive(ctx, league.Code); err != nil {
+			errs = append(errs, fmt.Errorf("%s live: %w", league.Code, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func inKickoffWindow(kickoffs []time.Time, now time.Time) bool {
+	for _, k := range kickoffs {
+		if !now.Before(k.Add(-liveLead)) && !now.After(k.Add(matchLength)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+)
+
+var kickoff = time.Date(2024, 3, 2, 13, 0, 0, 0, time.UTC)
+
+func TestInKickoffWindow(t *testing.T) {
+	for _, tc := range []struct {
+		at   time.Time
+		want bool
+	}{
+		{kickoff.Add(-liveLead - time.Second), false},
+		{kickoff.Add(-liveLead), true},
+		{kickoff.Add(time.Hour), true},
+		{kickoff.Add(matchLength), true},
+		{kickoff.Add(matchLength + time.Second), false},
+	} {
+		if got := inKickoffWindow([]time.Time{kickoff}, tc.at); got != tc.want {
+			t.Errorf("at kickoff%+v: in window = %v, want %v", tc.at.Sub(kickoff), got, tc.want)
+		}
+	}
+	if inKickoffWindow(nil, kickoff) {
+		t.Error("in window with no kickoffs")
+	}
+}
+
+func newWorker(t *testing.T, jobs *memoryJobs, standings *refreshCounter, now time.Time) *IngestionWorker {
+	t.Helper()
+	leagues, err := infrastructure.NewLeagueRegistry([]domain.LeagueConfig{
+		{Code: "ETH", APISportsID: 363},
+		{Code: "EPL", APISportsID: 39},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewIngestionWorker(jobs, leagues, fixedSeason{}, nil, nil, standings, nil, time.Minute)
+	w.now = func() time.Time { return now }
+	return w
+}
+
+func TestRefreshStandingsOnlyWhereMatchesEnded(t *testing.T) {
+	jobs := &memoryJobs{
+		last: map[string]time.Time{"standings": kickoff.Add(matchLength - 10*time.Minute)},
+		kickoffs: map[string][]time.Time{
+			"ETH": {kickoff},                      // ended since the last run
+			"EPL": {kickoff.Add(-24 * time.Hour)}, // ended before it
+		},
+	}
+	standings := &refreshCounter{}
+	w := newWorker(t, jobs, standings, kickoff.Add(matchLength+time.Minute))
+
+	if err := w.RefreshStandings(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(standings.leagues) != 1 || standings.leagues[0] != 363 {
+		t.Errorf("refreshed leagues %v, want only ETH (363)", standings.leagues)
+	}
+
+	// a match still in play is not over yet
+	standings.leagues = nil
+	w.now = func() time.Time { return kickoff.Add(matchLength - time.Minute) }
+	if err := w.RefreshStandings(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(standings.leagues) != 0 {
+		t.Errorf("refreshed leagues %v before any match ended", standings.leagues)
+	}
+}
+
+func TestRefreshStandingsFirstRunCoversEveryLeague(t *testing.T) {
+	standings := &refreshCounter{}
+	w := newWorker(t, &memoryJobs{}, standings, kickoff)
+
+	if err := w.RefreshStandings(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(standings.leagues) != 2 {
+		t.Errorf("refreshed leagues %v on the first run, want both", standings.leagues)
+	}
+}
+
+func TestRunOnceSkipsLeasedRuns(t *testing.T) {
+	jobs := &memoryJobs{leased: map[string]bool{"fixtures": true}}
+	w := newWorker(t, jobs, &refreshCounter{}, kickoff)
+
+	ran := false
+	job := Job{Name: "fixtures", Every: time.Hour, Run: func(ctx context.Context) error { ran = true; return nil }}
+	if err := w.runOnce(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	if ran {
+		t.Error("ran a job another replica holds the lease of")
+	}
+}
+
+func TestRunOnceReleasesFailedRuns(t *testing.T) {
+	jobs := &memoryJobs{}
+	w := newWorker(t, jobs, &refreshCounter{}, kickoff)
+
+	runs := 0
+	job := Job{Name: "fixtures", Every: 6 * time.Hour, Run: func(ctx context.Context) error {
+		runs++
+		if runs == 1 {
+			return errors.New("upstream blip")
+		}
+		return nil
+	}}
+
+	if err := w.runOnce(context.Background(), job); err == nil {
+		t.Fatal("failed run reported no error")
+	}
+	if !jobs.last["fixtures"].IsZero() {
+		t.Error("failed run recorded as completed")
+	}
+	if err := w.runOnce(context.Background(), job); err != nil {
+		t.Fatal(err)
+	}
+	if runs != 2 {
+		t.Errorf("%d runs, want the failed run retried at once", runs)
+	}
+	if err := w.runOnce(context.Background(), job); err != nil || runs != 2 {
+		t.Errorf("%d runs, want the successful run to keep its lease", runs)
+	}
+}
+
+func TestRetryDelayBacksOff(t *testing.T) {
+	for failures, want := range map[int]time.Duration{1: time.Minute, 2: 2 * time.Minute, 4: 8 * time.Minute, 20: time.Hour} {
+		if got := retryDelay(time.Hour, failures); got != want {
+			t.Errorf("retryDelay after %d failures = %v, want %v", failures, got, want)
+		}
+	}
+}
+
+// memoryJobs is an IJobRepo whose leases never expire.
+type memoryJobs struct {
+	leased   map[string]bool
+	last     map[string]time.Time
+	kickoffs map[string][]time.Time
+}
+
+func (m *memoryJobs) Claim(ctx context.Context, job string, ttl time.Duration) (bool, error) {
+	if m.leased == nil {
+		m.leased = map[string]bool{}
+	}
+	if m.leased[job] {
+		return false, nil
+	}
+	m.leased[job] = true
+	return true, nil
+}
+
+func (m *memoryJobs) Release(ctx context.Context, job string) error {
+	delete(m.leased, job)
+	return nil
+}
+
+func (m *memoryJobs) LastRun(ctx context.Context, job string) (time.Time, error) {
+	return m.last[job], nil
+}
+
+func (m *memoryJobs) SetLastRun(ctx context.Context, job string, at time.Time) error {
+	if m.last == nil {
+		m.last = map[string]time.Time{}
+	}
+	m.last[job] = at
+	return nil
+}
+
+func (m *memoryJobs) SaveKickoffs(ctx context.Context, league string, kickoffs []time.Time) error {
+	m.kickoffs[league] = kickoffs
+	return nil
+}
+
+func (m *memoryJobs) Kickoffs(ctx context.Context, league string) ([]time.Time, error) {
+	return m.kickoffs[league], nil
+}
+
+type fixedSeason struct{ ISeasonCalendar }
+
+func (fixedSeason) CurrentSeason(ctx context.Context, league string) (int, error) { return 2023, nil }
+
+// refreshCounter records the leagues whose table was refreshed.
+type refreshCounter struct {
+	domain.IStandingsRepo
+	leagues []int
+}
+
+func (r *refreshCounter) RefreshStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
+	r.leagues = append(r.leagues, leagueID)
+	return &domain.StandingsResponse{}, nil
+}
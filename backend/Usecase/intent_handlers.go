@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+// IntentRequest is a parsed intent with its league and current season
+// resolved, handed to the handler of its topic.
+type IntentRequest struct {
+	Intent *domain.Intent
+	League *domain.LeagueConfig // nil when the intent names no configured league
+	Season int
+	Dates  ethiotime.Options
+}
+
+// IntentHandler gathers the data a topic is answered from. It fills
+// answer.ContextData (under "data") and answer.Dates.
+type IntentHandler interface {
+	Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error
+}
+
+// IntentHandlerFunc adapts a function to IntentHandler.
+type IntentHandlerFunc func(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error
+
+func (f IntentHandlerFunc) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	return f(ctx, req, answer)
+}
+
+// IntentRegistry dispatches intents to the handler registered for their
+// topic, so a new topic is a new handler rather than a controller change.
+type IntentRegistry struct {
+	handlers map[string]IntentHandler
+	leagues  domain.ILeagueRegistry
+	seasons  ISeasonCalendar
+}
+
+func NewIntentRegistry(leagues domain.ILeagueRegistry, seasons ISeasonCalendar) *IntentRegistry {
+	return &IntentRegistry{handlers: map[string]IntentHandler{}, leagues: leagues, seasons: seasons}
+}
+
+// Register sets the handler of topic, replacing any earlier one.
+func (r *IntentRegistry) Register(topic string, h IntentHandler) {
+	r.handlers[topic] = h
+}
+
+// Topics lists the registered topics in alphabetical order.
+func (r *IntentRegistry) Topics() []string {
+	topics := make([]string, 0, len(r.handlers))
+	for topic := range r.handlers {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// Dispatch runs the handler of the intent's topic and returns the context
+// the answer is composed from.
+func (r *IntentRegistry) Dispatch(ctx context.Context, intent *domain.Intent) (*domain.AnswerContext, error) {
+	h, ok := r.handlers[intent.Topic]
+	if !ok {
+		return nil, ErrUnsupportedTopic
+	}
+
+	req := IntentRequest{Intent: intent, Dates: intentDateOptions(intent.Language)}
+	if league, err := r.leagues.ByCode(intent.League); err == nil {
+		req.League = league
+		if req.Season, err = r.seasons.CurrentSeason(ctx, league.Code); err != nil {
+			fmt.Printf("intent: no current season for %s: %v\n", league.Code, err)
+		}
+	}
+
+	answer := &domain.AnswerContext{
+		Topic:       intent.Topic,
+		Language:    intent.Language,
+		ContextData: map[string]interface{}{},
+	}
+	if err := h.Handle(ctx, req, answer); err != nil {
+		return nil, err
+	}
+	if req.Season != 0 {
+		answer.ContextData["season"] = req.Season
+	}
+	if len(intent.Teams) > 0 {
+		answer.ContextData["teams"] = intent.Teams
+	}
+
+	// Report the age of the oldest data the answer is built on
+	freshness, source, ok := domain.OldestFreshness(ctx)
+	if !ok {
+		freshness, source = time.Now(), "api"
+	}
+	answer.Freshness, answer.Source = freshness, source
+	return answer, nil
+}
+
+// intentDateOptions picks how dates are shown in composed answers: always in
+// East Africa Time, and in the Ethiopian calendar when the user wrote in Amharic.
+func intentDateOptions(language string) ethiotime.Options {
+	switch language {
+	case "am", "amharic", "Amharic":
+		opts, _ := ethiotime.ParseOptions(ethiotime.Ethiopian, "EAT", "am")
+		return opts
+	default:
+		opts, _ := ethiotime.ParseOptions(ethiotime.Gregorian, "EAT", "en")
+		return opts
+	}
+}
@@ -0,0 +1,76 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func TestIntentRegistryDispatchesByTopic(t *testing.T) {
+	leagues, _ := loadConfig(t)
+	registry := usecase.NewIntentRegistry(leagues, seasonOf{season: 2023})
+
+	var got usecase.IntentRequest
+	record := func(name string) usecase.IntentHandler {
+		return usecase.IntentHandlerFunc(func(ctx context.Context, req usecase.IntentRequest, answer *domain.AnswerContext) error {
+			got = req
+			answer.ContextData["data"] = name
+			return nil
+		})
+	}
+	registry.Register("table", record("first"))
+	registry.Register("table", record("table"))
+	registry.Register("news", record("news"))
+
+	if topics := registry.Topics(); !slices.Equal(topics, []string{"news", "table"}) {
+		t.Errorf("topics = %v, want news and table", topics)
+	}
+
+	answer, err := registry.Dispatch(context.Background(), &domain.Intent{Topic: "table", League: "ETH", Teams: []string{"Kedus Giorgis"}, Language: "amharic"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer.ContextData["data"] != "table" {
+		t.Errorf("answered by %v, want the handler registered last", answer.ContextData["data"])
+	}
+	if got.League == nil || got.League.Code != "ETH" || got.Season != 2023 {
+		t.Errorf("request = %+v, want ETH in its current season", got)
+	}
+	if answer.ContextData["season"] != 2023 || !slices.Equal(answer.ContextData["teams"].([]string), []string{"Kedus Giorgis"}) {
+		t.Errorf("context = %v, want the season and teams added", answer.ContextData)
+	}
+	if got.Dates.Calendar != ethiotime.Ethiopian || got.Dates.Location != ethiotime.EAT || got.Dates.Language != "am" {
+		t.Errorf("dates = %+v, want Ethiopian dates in EAT for an Amharic query", got.Dates)
+	}
+	if answer.Source == "" || answer.Freshness.IsZero() {
+		t.Errorf("answer carries no freshness: %+v", answer)
+	}
+
+	if _, err := registry.Dispatch(context.Background(), &domain.Intent{Topic: "news", League: "XYZ", Language: "english"}); err != nil {
+		t.Fatal(err)
+	}
+	if got.League != nil || got.Season != 0 || got.Dates.Calendar != ethiotime.Gregorian {
+		t.Errorf("request = %+v, want no league and Gregorian dates", got)
+	}
+
+	if _, err := registry.Dispatch(context.Background(), &domain.Intent{Topic: "weather"}); !errors.Is(err, usecase.ErrUnsupportedTopic) {
+		t.Errorf("unknown topic: err = %v, want ErrUnsupportedTopic", err)
+	}
+}
+
+func TestIntentRegistryPassesHandlerErrors(t *testing.T) {
+	leagues, _ := loadConfig(t)
+	registry := usecase.NewIntentRegistry(leagues, seasonOf{season: 2023})
+	registry.Register("fixture", usecase.IntentHandlerFunc(func(ctx context.Context, req usecase.IntentRequest, answer *domain.AnswerContext) error {
+		return domain.ErrNoData
+	}))
+
+	if _, err := registry.Dispatch(context.Background(), &domain.Intent{Topic: "fixture", League: "EPL"}); !errors.Is(err, domain.ErrNoData) {
+		t.Errorf("err = %v, want the handler's error", err)
+	}
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+// intentFixtureLimit caps the upcoming and result lists of a fixture answer.
+const intentFixtureLimit = 10
+
+// FixtureIntentHandler answers "fixture" intents with the upcoming matches
+// and latest results of the league, narrowed to the first team and the date
+// when the intent names them.
+type FixtureIntentHandler struct {
+	fixtures FixtureUsecase
+	teams    ITeamResolver
+}
+
+func NewFixtureIntentHandler(fixtures FixtureUsecase, teams ITeamResolver) *FixtureIntentHandler {
+	return &FixtureIntentHandler{fixtures: fixtures, teams: teams}
+}
+
+func (h *FixtureIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	if req.League == nil {
+		return domain.ErrLeagueNotFound
+	}
+
+	team := ""
+	if len(req.Intent.Teams) > 0 {
+		id, err := h.teams.ResolveID(ctx, req.Intent.Teams[0], req.League.Code)
+		if err != nil {
+			fmt.Printf("intent: no team id for %q: %v\n", req.Intent.Teams[0], err)
+		} else {
+			team = strconv.Itoa(id)
+		}
+	}
+	season := ""
+	if req.Season != 0 {
+		season = strconv.Itoa(req.Season)
+	}
+
+	fixtures, err := h.fixtures.GetFixtures(ctx, req.League.Code, team, season, req.Intent.Date, req.Intent.Date)
+	if err != nil {
+		return err
+	}
+
+	upcoming, results := []domain.Fixture{}, []domain.Fixture{}
+	for _, f := range fixtures {
+		f.DateLocal = req.Dates.FormatTimestamp(f.DateUTC)
+		if f.Status == "finished" {
+			results = append(results, f)
+		} else {
+			upcoming = append(upcoming, f)
+		}
+	}
+	sort.Slice(upcoming, func(i, j int) bool { return upcoming[i].DateUTC < upcoming[j].DateUTC })
+	sort.Slice(results, func(i, j int) bool { return results[i].DateUTC > results[j].DateUTC })
+	if len(upcoming) > intentFixtureLimit {
+		upcoming = upcoming[:intentFixtureLimit]
+	}
+	if len(results) > intentFixtureLimit {
+		results = results[:intentFixtureLimit]
+	}
+
+	answer.ContextData["data"] = map[string]interface{}{"upcoming": upcoming, "results": results}
+	answer.Dates = append(kickoffDates(upcoming, req.Dates), kickoffDates(results, req.Dates)...)
+	return nil
+}
+
+// StandingsIntentHandler answers "table" intents with the league table.
+type StandingsIntentHandler struct {
+	standings IStandingsUsecase
+}
+
+func NewStandingsIntentHandler(standings IStandingsUsecase) *StandingsIntentHandler {
+	return &StandingsIntentHandler{standings: standings}
+}
+
+func (h *StandingsIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	if req.League == nil {
+		return domain.ErrLeagueNotFound
+	}
+
+	table, err := h.standings.GetStandings(ctx, req.League.APISportsID, req.Season)
+	if err != nil {
+		return err
+	}
+	answer.ContextData["data"] = table
+	return nil
+}
+
+// NewsIntentHandler answers "news" intents with the generated headlines.
+type NewsIntentHandler struct {
+	news *NewsUseCase
+}
+
+func NewNewsIntentHandler(news *NewsUseCase) *NewsIntentHandler {
+	return &NewsIntentHandler{news: news}
+}
+
+func (h *NewsIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	var news []any
+	if ans, err := h.news.GenerateStandingNews(); err == nil {
+		news = append(news, ans)
+	}
+	if ans, err := h.news.GenerateFutureNews(req.Dates); err == nil {
+		news = append(news, ans)
+	}
+	if ans, err := h.news.GenerateLiveScores(req.Dates); err == nil {
+		news = append(news, ans)
+	}
+	if ans, err := h.news.GenerateNews(req.Dates); err == nil {
+		news = append(news, ans)
+	}
+	answer.ContextData["data"] = news
+	return nil
+}
+
+// compareMeetings is how many previous meetings a comparison cites.
+const compareMeetings = 5
+
+// CompareIntentHandler answers "compare" intents with the season
+// statistics of the first two teams and their recent meetings.
+type CompareIntentHandler struct {
+	teams    TeamUsecases
+	resolver ITeamResolver
+}
+
+func NewCompareIntentHandler(teams TeamUsecases, resolver ITeamResolver) *CompareIntentHandler {
+	return &CompareIntentHandler{teams: teams, resolver: resolver}
+}
+
+func (h *CompareIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	if len(req.Intent.Teams) < 2 {
+		return fmt.Errorf("%w: two teams are required for comparison", ErrInvalidInput)
+	}
+	if req.League == nil {
+		return domain.ErrLeagueNotFound
+	}
+
+	teamA, err := h.teams.Statistics(ctx, req.League.APISportsID, req.Season, req.Intent.Teams[0])
+	if err != nil {
+		return fmt.Errorf("statistics of %s: %w", req.Intent.Teams[0], err)
+	}
+	teamB, err := h.teams.Statistics(ctx, req.League.APISportsID, req.Season, req.Intent.Teams[1])
+	if err != nil {
+		return fmt.Errorf("statistics of %s: %w", req.Intent.Teams[1], err)
+	}
+
+	answer.ContextData["data"] = domain.ComparisonData{TeamA: teamA, TeamB: teamB}
+
+	// the meetings enrich the answer; it stands without them
+	idA, errA := h.resolver.ResolveID(ctx, req.Intent.Teams[0], req.League.Code)
+	idB, errB := h.resolver.ResolveID(ctx, req.Intent.Teams[1], req.League.Code)
+	if errA == nil && errB == nil {
+		h2h, err := h.teams.HeadToHead(ctx, idA, idB, compareMeetings)
+		if err != nil {
+			fmt.Println("intent: head to head failed:", err)
+		} else {
+			answer.ContextData["head_to_head"] = h2h
+		}
+	}
+	return nil
+}
+
+// FactIntentHandler answers "fact" intents with the profile of each named
+// team, or just its name when the team is not known upstream.
+type FactIntentHandler struct {
+	teams    TeamUsecases
+	resolver ITeamResolver
+}
+
+func NewFactIntentHandler(teams TeamUsecases, resolver ITeamResolver) *FactIntentHandler {
+	return &FactIntentHandler{teams: teams, resolver: resolver}
+}
+
+func (h *FactIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	league := ""
+	if req.League != nil {
+		league = req.League.Code
+	}
+
+	facts := make([]any, 0, len(req.Intent.Teams))
+	for _, name := range req.Intent.Teams {
+		id, err := h.resolver.ResolveID(ctx, name, league)
+		if err == nil {
+			if team, err := h.teams.GetTeamByID(ctx, id); err == nil {
+				facts = append(facts, team)
+				continue
+			}
+		}
+		facts = append(facts, name)
+	}
+	answer.ContextData["data"] = facts
+	return nil
+}
+
+// kickoffDates lists the localized kickoff of every fixture for an AnswerContext.
+func kickoffDates(fixtures []domain.Fixture, opts ethiotime.Options) []domain.LocalizedDate {
+	dates := make([]domain.LocalizedDate, 0, len(fixtures))
+	for _, f := range fixtures {
+		dates = append(dates, domain.LocalizedDate{
+			Label: f.HomeName + " vs " + f.AwayName,
+			UTC:   f.DateUTC,
+			Local: opts.FormatTimestamp(f.DateUTC),
+		})
+	}
+	return dates
+}
+
+// intentScorerLimit caps the scoring chart of a player answer.
+const intentScorerLimit = 10
+
+// PlayerIntentHandler answers "player" intents with the league's scoring
+// chart and, when the intent names a team, that team's squad and scorers.
+type PlayerIntentHandler struct {
+	players  IPlayerUsecase
+	resolver ITeamResolver
+}
+
+func NewPlayerIntentHandler(players IPlayerUsecase, resolver ITeamResolver) *PlayerIntentHandler {
+	return &PlayerIntentHandler{players: players, resolver: resolver}
+}
+
+func (h *PlayerIntentHandler) Handle(ctx context.Context, req IntentRequest, answer *domain.AnswerContext) error {
+	if req.League == nil {
+		return domain.ErrLeagueNotFound
+	}
+
+	scorers, err := h.players.TopScorers(ctx, req.League.Code, req.Season)
+	if err != nil {
+		return err
+	}
+	data := map[string]interface{}{}
+
+	if len(req.Intent.Teams) > 0 {
+		teamID, err := h.resolver.ResolveID(ctx, req.Intent.Teams[0], req.League.Code)
+		if err != nil {
+			fmt.Printf("intent: no team id for %q: %v\n", req.Intent.Teams[0], err)
+		} else {
+			teamScorers := []domain.TopScorer{}
+			for _, s := range scorers {
+				if s.Team.ID == teamID {
+					teamScorers = append(teamScorers, s)
+				}
+			}
+			scorers = teamScorers
+			if squad, err := h.players.Squad(ctx, teamID, req.Season); err == nil {
+				data["squad"] = squad
+			} else {
+				fmt.Printf("intent: squad of %d failed: %v\n", teamID, err)
+			}
+		}
+	}
+
+	if len(scorers) > intentScorerLimit {
+		scorers = scorers[:intentScorerLimit]
+	}
+	data["top_scorers"] = scorers
+	answer.ContextData["data"] = data
+	return nil
+}
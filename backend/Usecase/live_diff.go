@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// api-sports short status codes that end a match.
+var finishedStatuses = map[string]bool{"FT": true, "AET": true, "PEN": true}
+
+// api-sports short status codes for a match that has not kicked off.
+var pendingStatuses = map[string]bool{"": true, "TBD": true, "NS": true}
+
+// api-sports short status codes for a match in play.
+var inPlayStatuses = map[string]bool{"1H": true, "HT": true, "2H": true, "ET": true, "BT": true, "P": true, "LIVE": true}
+
+// DiffLiveFixtures compares two live snapshots of a league and returns the
+// events that happened in between. A fixture that drops out of the live
+// feed has stopped, but not necessarily at full time: ended holds the state
+// it was looked up in, and it is reported as finished only when that state
+// is final. Fixtures missing from ended are not reported.
+func DiffLiveFixtures(league string, prev, next []domain.PrevFixtures, ended map[int]domain.PrevFixtures, at time.Time) []domain.LiveEvent {
+	before := make(map[int]domain.PrevFixtures, len(prev))
+	for _, f := range prev {
+		before[f.FixtureID] = f
+	}
+
+	var events []domain.LiveEvent
+	seen := make(map[int]bool, len(next))
+
+	for _, f := range next {
+		f := f
+		seen[f.FixtureID] = true
+		event := func(kind string, team domain.MTeam) domain.LiveEvent {
+			return domain.LiveEvent{Type: kind, League: league, FixtureID: f.FixtureID, Team: team.Name, TeamID: team.ID, Fixture: &f, At: at}
+		}
+
+		old, ok := before[f.FixtureID]
+		oldStatus := ""
+		if ok {
+			oldStatus = old.Status.Short
+		}
+		newStatus := f.Status.Short
+
+		if pendingStatuses[oldStatus] && !pendingStatuses[newStatus] {
+			events = append(events, event(domain.LiveEventKickoff, domain.MTeam{}))
+		}
+
+		home, away := goalsOf(old.Goals.Home), goalsOf(old.Goals.Away)
+		for ok && home < goalsOf(f.Goals.Home) {
+			home++
+			e := event(domain.LiveEventGoal, f.HomeTeam)
+			e.Score = fmt.Sprintf("%d-%d", home, away)
+			events = append(events, e)
+		}
+		for ok && away < goalsOf(f.Goals.Away) {
+			away++
+			e := event(domain.LiveEventGoal, f.AwayTeam)
+			e.Score = fmt.Sprintf("%d-%d", home, away)
+			events = append(events, e)
+		}
+
+		switch {
+		case finishedStatuses[newStatus] && !finishedStatuses[oldStatus]:
+			events = append(events, event(domain.LiveEventFullTime, domain.MTeam{}))
+		case ok && oldStatus != newStatus && !pendingStatuses[oldStatus]:
+			events = append(events, event(domain.LiveEventStatus, domain.MTeam{}))
+		}
+	}
+
+	for _, f := range prev {
+		if seen[f.FixtureID] || finishedStatuses[f.Status.Short] {
+			continue
+		}
+		final, ok := ended[f.FixtureID]
+		if !ok {
+			continue
+		}
+		kind := domain.LiveEventStatus
+		if finishedStatuses[final.Status.Short] {
+			kind = domain.LiveEventFullTime
+		}
+		events = append(events, domain.LiveEvent{
+			Type:      kind,
+			League:    league,
+			FixtureID: final.FixtureID,
+			Fixture:   &final,
+			At:        at,
+		})
+	}
+
+	return events
+}
+
+func goalsOf(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
@@ -0,0 +1,33 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func inPlay(id int, short string) domain.PrevFixtures {
+	return domain.PrevFixtures{FixtureID: id, Status: domain.Status{Short: short}}
+}
+
+func TestFixturesLeavingTheFeedEndOnlyWhenFinal(t *testing.T) {
+	prev := []domain.PrevFixtures{inPlay(1, "2H"), inPlay(2, "2H"), inPlay(3, "1H")}
+	ended := map[int]domain.PrevFixtures{
+		1: inPlay(1, "FT"),
+		2: inPlay(2, "ABD"),
+		// 3 could not be looked up yet
+	}
+
+	events := usecase.DiffLiveFixtures("ETH", prev, nil, ended, time.Now())
+
+	got := map[int]string{}
+	for _, e := range events {
+		got[e.FixtureID] = e.Type + " " + e.Fixture.Status.Short
+	}
+	want := map[int]string{1: "full_time FT", 2: "status ABD"}
+	if len(got) != len(want) || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+type ILiveHub interface {
+	// Subscribe returns a channel of live events for the league and a
+	// function that must be called to unsubscribe.
+	Subscribe(league string) (<-chan domain.LiveEvent, func())
+	// Listen delivers every live event of the league to events, which the
+	// caller owns and the hub never closes, until the returned function is
+	// called. Unlike Subscribe, events are not dropped for a slow reader;
+	// the league's poller waits for it.
+	Listen(league string, events chan<- domain.LiveEvent) func()
+}
+
+// LiveHub runs one poller per league and fans its events out to every
+// subscriber, so N clients cost one lookup per interval. The pollers read
+// the league's live snapshot, which the ingestion worker keeps up to date
+// while matches are in play, so they reach upstream only when no worker
+// runs and a match may be on. A poller starts with its first subscriber
+// and stops after its last.
+type LiveHub struct {
+	fixtures IFixturesUsecase
+	interval time.Duration
+
+	mu      sync.Mutex
+	pollers map[string]*livePoller
+}
+
+type livePoller struct {
+	league    string
+	subs      map[chan domain.LiveEvent]bool // true once it missed an event
+	listeners map[*liveListener]struct{}
+	snapshot  []domain.PrevFixtures
+	polled    bool
+	stop      chan struct{}
+}
+
+type liveListener struct {
+	events chan<- domain.LiveEvent
+	done   chan struct{}
+}
+
+func NewLiveHub(fixtures IFixturesUsecase, interval time.Duration) *LiveHub {
+	return &LiveHub{
+		fixtures: fixtures,
+		interval: interval,
+		pollers:  map[string]*livePoller{},
+	}
+}
+
+func (h *LiveHub) Subscribe(league string) (<-chan domain.LiveEvent, func()) {
+	ch := make(chan domain.LiveEvent, 32)
+
+	h.mu.Lock()
+	p := h.poller(league)
+	p.subs[ch] = false
+	if p.polled {
+		ch <- h.snapshotEvent(p)
+	}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(p.subs, ch)
+			close(ch)
+			h.release(p)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *LiveHub) Listen(league string, events chan<- domain.LiveEvent) func() {
+	l := &liveListener{events: events, done: make(chan struct{})}
+
+	h.mu.Lock()
+	p := h.poller(league)
+	p.listeners[l] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(l.done)
+
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(p.listeners, l)
+			h.release(p)
+		})
+	}
+}
+
+// poller returns the league's poller, starting it if there is none. h.mu
+// must be held.
+func (h *LiveHub) poller(league string) *livePoller {
+	if p, ok := h.pollers[league]; ok {
+		return p
+	}
+	p := &livePoller{
+		league:    league,
+		subs:      map[chan domain.LiveEvent]bool{},
+		listeners: map[*liveListener]struct{}{},
+		stop:      make(chan struct{}),
+	}
+	h.pollers[league] = p
+	go h.run(p)
+	return p
+}
+
+// release stops p once nobody subscribes or listens to it. h.mu must be
+// held.
+func (h *LiveHub) release(p *livePoller) {
+	if len(p.subs) == 0 && len(p.listeners) == 0 && h.pollers[p.league] == p {
+		close(p.stop)
+		delete(h.pollers, p.league)
+	}
+}
+
+func (h *LiveHub) run(p *livePoller) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		h.poll(p)
+
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// endedFixtures looks up the fixtures of prev that are missing from next.
+// Those not found or still shown in play are returned as pending, to stay
+// in the snapshot until a later poll settles them, unless they kicked off
+// so long ago that they are given up on.
+func (h *LiveHub) endedFixtures(ctx context.Context, prev, next []domain.PrevFixtures) (map[int]domain.PrevFixtures, []domain.PrevFixtures) {
+	live := make(map[int]bool, len(next))
+	for _, f := range next {
+		live[f.FixtureID] = true
+	}
+
+	ended := map[int]domain.PrevFixtures{}
+	var pending []domain.PrevFixtures
+	for _, f := range prev {
+		if live[f.FixtureID] || finishedStatuses[f.Status.Short] {
+			continue
+		}
+		detail, err := h.fixtures.MatchDetail(ctx, f.FixtureID)
+		if err == nil && !inPlayStatuses[detail.Status.Short] && !pendingStatuses[detail.Status.Short] {
+			ended[f.FixtureID] = detail.PrevFixtures
+			continue
+		}
+		if kickoff, perr := time.Parse(time.RFC3339, f.Date); perr == nil && time.Since(kickoff) > matchSettleTime {
+			fmt.Printf("giving up on the final state of fixture %d\n", f.FixtureID)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("could not look up fixture %d after it left the live feed: %v\n", f.FixtureID, err)
+		}
+		pending = append(pending, f)
+	}
+	return ended, pending
+}
+
+func (h *LiveHub) poll(p *livePoller) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.interval)
+	defer cancel()
+	fixtures, err := h.fixtures.GetLiveMatches(ctx, p.league)
+	if err != nil {
+		fmt.Printf("live poll failed (league=%s): %v\n", p.league, err)
+		return
+	}
+
+	var next []domain.PrevFixtures
+	if fixtures != nil {
+		next = *fixtures
+	}
+
+	// fixtures that left the feed are looked up first, so a match is called
+	// finished only once it is; p.snapshot is written by this goroutine alone
+	var ended map[int]domain.PrevFixtures
+	if p.polled {
+		var pending []domain.PrevFixtures
+		ended, pending = h.endedFixtures(ctx, p.snapshot, next)
+		next = append(slices.Clip(next), pending...)
+	}
+
+	h.mu.Lock()
+	var events []domain.LiveEvent
+	if p.polled {
+		events = DiffLiveFixtures(p.league, p.snapshot, next, ended, time.Now().UTC())
+	}
+
+	first := !p.polled
+	p.snapshot = next
+	p.polled = true
+
+	if first {
+		events = append(events, h.snapshotEvent(p))
+	}
+
+	for ch, behind := range p.subs {
+		if behind {
+			// events are changes, so a subscriber that missed one starts
+			// over from the snapshot, which already holds this poll's
+			p.subs[ch] = !offer(ch, h.snapshotEvent(p))
+			continue
+		}
+		for _, e := range events {
+			if !offer(ch, e) {
+				p.subs[ch] = true
+				break
+			}
+		}
+	}
+	listeners := make([]*liveListener, 0, len(p.listeners))
+	for l := range p.listeners {
+		listeners = append(listeners, l)
+	}
+	h.mu.Unlock()
+
+	// listeners are waited for without the lock, so a slow one holds up
+	// only this league's poller
+	for _, e := range events {
+		for _, l := range listeners {
+			select {
+			case l.events <- e:
+			case <-l.done:
+			}
+		}
+	}
+}
+
+// offer sends e to ch unless its buffer is full.
+func offer(ch chan domain.LiveEvent, e domain.LiveEvent) bool {
+	select {
+	case ch <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *LiveHub) snapshotEvent(p *livePoller) domain.LiveEvent {
+	fixtures := append([]domain.PrevFixtures{}, p.snapshot...)
+	return domain.LiveEvent{
+		Type:     domain.LiveEventSnapshot,
+		League:   p.league,
+		Fixtures: fixtures,
+		At:       time.Now().UTC(),
+	}
+}
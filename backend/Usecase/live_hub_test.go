@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func TestSlowSubscriberGetsASnapshot(t *testing.T) {
+	// the second poll finds 40 goals, more than a subscriber buffers
+	feed := &scriptedFeed{scores: []int{0, 40}}
+	hub := usecase.NewLiveHub(feed, 10*time.Millisecond)
+	events, unsubscribe := hub.Subscribe("ETH")
+	defer unsubscribe()
+
+	for feed.polls() < 3 {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	deadline := time.After(2 * time.Second)
+	snapshots := 0
+	for {
+		select {
+		case e := <-events:
+			if e.Type != domain.LiveEventSnapshot {
+				continue
+			}
+			snapshots++
+			if snapshots == 1 {
+				continue
+			}
+			if len(e.Fixtures) != 1 || *e.Fixtures[0].Goals.Home != 40 {
+				t.Fatalf("catch-up snapshot = %+v, want the match at 40-0", e.Fixtures)
+			}
+			return
+		case <-deadline:
+			t.Fatal("a subscriber that missed events was never sent a fresh snapshot")
+		}
+	}
+}
+
+// scriptedFeed serves one live match whose home score follows scores, one
+// per poll, and then stays at the last.
+type scriptedFeed struct {
+	usecase.IFixturesUsecase
+	mu     sync.Mutex
+	scores []int
+	calls  int
+}
+
+func (f *scriptedFeed) GetLiveMatches(ctx context.Context, league string) (*[]domain.PrevFixtures, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	home := f.scores[min(f.calls, len(f.scores)-1)]
+	away := 0
+	f.calls++
+	return &[]domain.PrevFixtures{{
+		FixtureID: 7,
+		HomeTeam:  domain.MTeam{ID: 1, Name: "Saint George"},
+		AwayTeam:  domain.MTeam{ID: 2, Name: "Fasil Ketema"},
+		Goals:     domain.Goals{Home: &home, Away: &away},
+		Status:    domain.Status{Short: "2H"},
+	}}, nil
+}
+
+func (f *scriptedFeed) polls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
@@ -0,0 +1,404 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+const (
+	// newsRefreshInterval is how often reading the feed turns the latest
+	// events into stored stories.
+	newsRefreshInterval = 15 * time.Minute
+	// newsRetryDelay is the wait after a failed Publish; it doubles with
+	// each failure in a row, up to newsRefreshInterval.
+	newsRetryDelay = 30 * time.Second
+	// newsPublishTimeout bounds a background Publish.
+	newsPublishTimeout = 2 * time.Minute
+)
+
+// newsSource credits the data behind the generated stories.
+const newsSource = "TheSportsDB"
+
+// Publish turns the latest results, fixtures, live scores and table into
+// stored news items. Each story keeps its ID across runs, so a live score
+// is updated in place rather than repeated.
+func (uc *NewsUseCase) Publish(ctx context.Context) ([]domain.NewsItem, error) {
+	var items []domain.NewsItem
+	var errs []error
+
+	if events, err := uc.repo.GetPastEvents(); err == nil {
+		tables := uc.seasonTables(ctx)
+		for _, e := range events {
+			if !hasScore(e) {
+				continue
+			}
+			items = append(items, uc.resultItem(e, tables))
+		}
+	} else {
+		errs = append(errs, err)
+	}
+	if events, err := uc.repo.GetFutureEvents(); err == nil {
+		for _, e := range events {
+			items = append(items, uc.previewItem(e))
+		}
+	} else {
+		errs = append(errs, err)
+	}
+	if events, err := uc.repo.GetLiveScores(); err == nil {
+		for _, e := range events {
+			items = append(items, uc.liveItem(e))
+		}
+	}
+	if table, err := uc.repo.GetStandings(); err == nil && len(table) > 0 {
+		items = append(items, uc.standingsItem(table))
+	} else if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(items) == 0 && len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	for _, item := range items {
+		if err := uc.store.SaveNews(ctx, item); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.mu.Lock()
+	uc.publishedAt = uc.now()
+	uc.mu.Unlock()
+	return items, nil
+}
+
+// Feed returns stored news matching q, newest first. When the stories were
+// last published a while ago they are republished in the background, so
+// the reader is served the stored ones meanwhile. q.Team may be any
+// spelling of a team name.
+func (uc *NewsUseCase) Feed(ctx context.Context, q domain.NewsQuery) ([]domain.NewsItem, error) {
+	uc.refresh()
+
+	if q.Team != "" {
+		q.Team = uc.TeamTag(q.Team)
+	}
+	return uc.store.ListNews(ctx, q)
+}
+
+// refresh starts a background Publish when one is due. Only one runs at a
+// time, and failed runs are retried with backoff rather than on every read.
+func (uc *NewsUseCase) refresh() {
+	uc.mu.Lock()
+	now := uc.now()
+	if uc.publishing || now.Sub(uc.publishedAt) <= newsRefreshInterval || now.Before(uc.retryAt) {
+		uc.mu.Unlock()
+		return
+	}
+	uc.publishing = true
+	uc.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), newsPublishTimeout)
+		defer cancel()
+		_, err := uc.Publish(ctx)
+
+		uc.mu.Lock()
+		defer uc.mu.Unlock()
+		uc.publishing = false
+		if err != nil {
+			fmt.Println("could not publish news:", err)
+			delay := newsRetryDelay << uc.failures
+			if delay > newsRefreshInterval {
+				delay = newsRefreshInterval
+			} else {
+				uc.failures++
+			}
+			uc.retryAt = uc.now().Add(delay)
+			return
+		}
+		uc.failures = 0
+	}()
+}
+
+// Article returns a stored news item by ID.
+func (uc *NewsUseCase) Article(ctx context.Context, id string) (*domain.NewsItem, error) {
+	return uc.store.GetNews(ctx, id)
+}
+
+func (uc *NewsUseCase) resultItem(e domain.Event, tables *seasonTables) domain.NewsItem {
+	published := uc.now()
+	if t, ok := eventTime(e); ok && t.Before(published) {
+		published = t
+	}
+
+	return uc.newsItem("result-"+e.IDEvent, "result", published, []string{e.StrHomeTeam, e.StrAwayTeam}, func(lang string) (string, string, error) {
+		return uc.writer.MatchReport(lang, uc.matchFacts(e, lang, tables))
+	})
+}
+
+func (uc *NewsUseCase) previewItem(e domain.Event) domain.NewsItem {
+	return uc.newsItem("preview-"+e.IDEvent, "preview", uc.now(), []string{e.StrHomeTeam, e.StrAwayTeam}, func(lang string) (string, string, error) {
+		opts := reportDateOptions(lang)
+		kickoff := eventDate(e, opts)
+		if t, ok := eventTime(e); ok {
+			kickoff = opts.FormatDateTime(t)
+		}
+		data := map[string]any{
+			"Home":    uc.displayName(e.StrHomeTeam, lang),
+			"Away":    uc.displayName(e.StrAwayTeam, lang),
+			"Kickoff": kickoff,
+			"Venue":   e.StrVenue,
+		}
+		return uc.renderPair(lang, data, "preview_title", "preview_snippet")
+	})
+}
+
+func (uc *NewsUseCase) liveItem(e domain.Event) domain.NewsItem {
+	return uc.newsItem("live-"+e.IDEvent, "live", uc.now(), []string{e.StrHomeTeam, e.StrAwayTeam}, func(lang string) (string, string, error) {
+		data := map[string]any{
+			"Home":      uc.displayName(e.StrHomeTeam, lang),
+			"Away":      uc.displayName(e.StrAwayTeam, lang),
+			"HomeGoals": e.IntHomeScore,
+			"AwayGoals": e.IntAwayScore,
+			"Status":    e.StrStatus,
+		}
+		return uc.renderPair(lang, data, "live_title", "live_snippet")
+	})
+}
+
+// standingsItem is one story per day about the top of the table.
+func (uc *NewsUseCase) standingsItem(table []domain.LeaguePoint) domain.NewsItem {
+	leader, second := table[0], domain.LeaguePoint{}
+	for _, row := range table {
+		switch row.IntRank {
+		case "1":
+			leader = row
+		case "2":
+			second = row
+		}
+	}
+
+	day := uc.now().In(ethiotime.EAT).Format("2006-01-02")
+	id := fmt.Sprintf("standings-%s-%s", uc.league.Code, day)
+	return uc.newsItem(id, "standings", uc.now(), []string{leader.StrTeam, second.StrTeam}, func(lang string) (string, string, error) {
+		data := map[string]any{
+			"Leader":       uc.displayName(leader.StrTeam, lang),
+			"Points":       leader.IntPoints,
+			"Played":       leader.IntPlayed,
+			"Wins":         leader.IntWin,
+			"Draws":        leader.IntDraw,
+			"Losses":       leader.IntLoss,
+			"Second":       uc.displayName(second.StrTeam, lang),
+			"SecondPoints": second.IntPoints,
+		}
+		return uc.renderPair(lang, data, "standings_title", "standings_snippet")
+	})
+}
+
+// newsItem renders a story in every language of the report writer; the
+// English text doubles as the item's default title and snippet.
+func (uc *NewsUseCase) newsItem(id, kind string, published time.Time, teams []string, render func(lang string) (string, string, error)) domain.NewsItem {
+	item := domain.NewsItem{
+		ID:           id,
+		Kind:         kind,
+		Source:       newsSource,
+		URL:          "/news/" + url.PathEscape(id),
+		PublishedAt:  published.UTC().Format(time.RFC3339),
+		Teams:        []string{},
+		League:       uc.league.Code,
+		Language:     defaultReportLanguage,
+		Translations: map[string]domain.NewsText{},
+	}
+	for _, team := range teams {
+		if team != "" {
+			item.Teams = append(item.Teams, uc.TeamTag(team))
+		}
+	}
+
+	for _, lang := range uc.writer.Languages() {
+		title, snippet, err := render(lang)
+		if err != nil {
+			fmt.Printf("could not write %s news %s: %v\n", lang, id, err)
+			continue
+		}
+		item.Translations[lang] = domain.NewsText{Title: title, Snippet: snippet}
+	}
+	text := item.Translations[defaultReportLanguage]
+	item.Title, item.Snippet = text.Title, text.Snippet
+	return item
+}
+
+func (uc *NewsUseCase) renderPair(lang string, data any, titleKey, snippetKey string) (string, string, error) {
+	title, err := uc.writer.Render(lang, data, titleKey)
+	if err != nil {
+		return "", "", err
+	}
+	snippet, err := uc.writer.Render(lang, data, snippetKey)
+	if err != nil {
+		return "", "", err
+	}
+	return title, snippet, nil
+}
+
+// matchFacts describes a finished event for the report writer, with team
+// names and the date in lang. tables, when given, supplies the league
+// positions of both teams before and after the match.
+func (uc *NewsUseCase) matchFacts(e domain.Event, lang string, tables *seasonTables) domain.MatchFacts {
+	homeGoals, _ := strconv.Atoi(e.IntHomeScore)
+	awayGoals, _ := strconv.Atoi(e.IntAwayScore)
+
+	f := domain.MatchFacts{
+		Home:      uc.displayName(e.StrHomeTeam, lang),
+		Away:      uc.displayName(e.StrAwayTeam, lang),
+		HomeGoals: homeGoals,
+		AwayGoals: awayGoals,
+		Venue:     e.StrVenue,
+		Date:      eventDate(e, reportDateOptions(lang)),
+		Derby:     uc.league.IsDerby(uc.TeamTag(e.StrHomeTeam), uc.TeamTag(e.StrAwayTeam)),
+	}
+	home, homeOK := firstHalfGoals(e.StrHomeGoalDetails, homeGoals)
+	away, awayOK := firstHalfGoals(e.StrAwayGoalDetails, awayGoals)
+	if homeOK && awayOK {
+		f.HalftimeHome, f.HalftimeAway = &home, &away
+	}
+	if tables != nil {
+		home, away := uc.TeamTag(e.StrHomeTeam), uc.TeamTag(e.StrAwayTeam)
+		f.HomeRankBefore, f.HomeRankAfter = tables.around(e, home)
+		f.AwayRankBefore, f.AwayRankAfter = tables.around(e, away)
+	}
+	return f
+}
+
+// hasScore reports whether a past event has a final score; postponed and
+// abandoned matches come back without one.
+func hasScore(e domain.Event) bool {
+	_, homeErr := strconv.Atoi(e.IntHomeScore)
+	_, awayErr := strconv.Atoi(e.IntAwayScore)
+	return homeErr == nil && awayErr == nil
+}
+
+// firstHalfGoals counts the goals scored before half time in TheSportsDB's
+// goal details, "12':Name;45+1':Name;". The count is only trusted when the
+// details list every goal of the final score.
+func firstHalfGoals(details string, goals int) (int, bool) {
+	firstHalf, listed := 0, 0
+	for _, goal := range strings.Split(details, ";") {
+		minute, _, ok := strings.Cut(strings.TrimSpace(goal), "'")
+		if !ok {
+			continue
+		}
+		// stoppage time is written 45+2, so the base minute decides the half
+		base, _, _ := strings.Cut(minute, "+")
+		m, err := strconv.Atoi(base)
+		if err != nil {
+			return 0, false
+		}
+		listed++
+		if m <= 45 {
+			firstHalf++
+		}
+	}
+	if listed != goals {
+		return 0, false
+	}
+	return firstHalf, true
+}
+
+// reportDateOptions renders dates the way readers of lang expect them:
+// the Ethiopian calendar in East Africa Time for Amharic.
+func reportDateOptions(lang string) ethiotime.Options {
+	if lang == "am" {
+		return ethiotime.Options{Calendar: ethiotime.Ethiopian, Location: ethiotime.EAT, Language: "am"}
+	}
+	return ethiotime.Options{}
+}
+
+// displayName is a team's name for readers of lang: its Ge'ez alias for
+// Amharic when the alias list has one.
+func (uc *NewsUseCase) displayName(name, lang string) string {
+	if lang != "am" || name == "" {
+		return name
+	}
+	tag := uc.TeamTag(name)
+	for _, t := range uc.teams.Teams(uc.league.Code) {
+		if t.Name != tag {
+			continue
+		}
+		for _, alias := range t.Aliases {
+			if containsGeez(alias) {
+				return alias
+			}
+		}
+	}
+	return name
+}
+
+// TeamTag is the name a team's stories are filed under: the canonical name
+// from the alias list, so "St. George" and "Kidus Giorgis" share a feed.
+func (uc *NewsUseCase) TeamTag(name string) string {
+	if matches := uc.teams.Resolve(name, uc.league.Code, 1); len(matches) > 0 {
+		return matches[0].Name
+	}
+	return strings.TrimSpace(name)
+}
+
+// seasonTables looks up league positions in the daily standings snapshots,
+// loading each season's snapshots once per publish run.
+type seasonTables struct {
+	uc      *NewsUseCase
+	ctx     context.Context
+	seasons map[int][]rankDay
+}
+
+// rankDay is the rank of every team, by tag, in one snapshot.
+type rankDay struct {
+	date  string
+	ranks map[string]int
+}
+
+func (uc *NewsUseCase) seasonTables(ctx context.Context) *seasonTables {
+	return &seasonTables{uc: uc, ctx: ctx, seasons: map[int][]rankDay{}}
+}
+
+// around returns team's rank in the last snapshot before the match day and
+// in the first one after it, 0 when there is none.
+func (t *seasonTables) around(e domain.Event, team string) (int, int) {
+	season, err := strconv.Atoi(strings.SplitN(e.StrSeason, "-", 2)[0])
+	if err != nil || len(e.DateEvent) < 10 {
+		return 0, 0
+	}
+
+	days, ok := t.seasons[season]
+	if !ok {
+		snapshots, err := t.uc.standings.GetSnapshots(t.ctx, t.uc.league.APISportsID, season)
+		if err != nil {
+			fmt.Println("could not load standings snapshots:", err)
+		}
+		for _, s := range snapshots {
+			day := rankDay{date: s.Date, ranks: map[string]int{}}
+			for _, row := range s.Standings {
+				day.ranks[t.uc.TeamTag(row.TeamName)] = row.Rank
+			}
+			days = append(days, day)
+		}
+		t.seasons[season] = days
+	}
+
+	matchDay := e.DateEvent[:10]
+	before, after := 0, 0
+	for _, day := range days {
+		switch {
+		case day.date < matchDay:
+			before = day.ranks[team]
+		case day.date > matchDay && after == 0:
+			after = day.ranks[team]
+		}
+	}
+	return before, after
+}
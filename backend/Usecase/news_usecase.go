@@ -2,10 +2,11 @@ package usecase
 
 import (
 	"fmt"
-	"strconv"
+	"sync"
 	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
 )
 
 type EventRepository interface {
@@ -16,15 +17,63 @@ type EventRepository interface {
 }
 
 type NewsUseCase struct {
-	repo EventRepository
+	repo      EventRepository
+	store     domain.INewsRepo
+	standings domain.IStandingsRepo
+	teams     ITeamResolver
+	writer    *ReportWriter
+	league    *domain.LeagueConfig // the league repo reports on
+	now       func() time.Time
+
+	mu          sync.Mutex
+	publishedAt time.Time
+	publishing  bool      // a background Publish is running
+	failures    int       // Publish runs failed in a row
+	retryAt     time.Time // no Publish is started before then
 }
 
-func NewNewsUseCase(repo EventRepository) *NewsUseCase {
-	return &NewsUseCase{repo: repo}
+func NewNewsUseCase(
+	repo EventRepository,
+	store domain.INewsRepo,
+	standings domain.IStandingsRepo,
+	teams ITeamResolver,
+	writer *ReportWriter,
+	league *domain.LeagueConfig,
+) *NewsUseCase {
+	return &NewsUseCase{
+		repo:      repo,
+		store:     store,
+		standings: standings,
+		teams:     teams,
+		writer:    writer,
+		league:    league,
+		now:       time.Now,
+	}
+}
+
+// League returns the league the news is written about.
+func (uc *NewsUseCase) League() *domain.LeagueConfig {
+	return uc.league
+}
+
+// eventTime returns the kickoff of a TheSportsDB event, which reports
+// dateEvent and strTime in UTC.
+func eventTime(e domain.Event) (time.Time, bool) {
+	if t, err := time.Parse("2006-01-02 15:04:05", e.DateEvent+" "+e.StrTime); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+func eventDate(e domain.Event, opts ethiotime.Options) string {
+	if t, ok := eventTime(e); ok {
+		return opts.FormatDate(t)
+	}
+	return opts.FormatTimestamp(e.DateEvent)
 }
 
 // --- Past Events ---
-func (uc *NewsUseCase) GenerateNews() ([]string, error) {
+func (uc *NewsUseCase) GenerateNews(opts ethiotime.Options) ([]string, error) {
 	events, err := uc.repo.GetPastEvents()
 	if err != nil {
 		return nil, err
@@ -32,25 +81,17 @@ func (uc *NewsUseCase) GenerateNews() ([]string, error) {
 
 	var news []string
 	for _, e := range events {
-		dateStr := e.DateEvent
-		if parsedDate, err := time.Parse("2006-01-02", e.DateEvent); err == nil {
-			dateStr = parsedDate.Format("02 Jan 2006")
+		if !hasScore(e) {
+			continue
 		}
+		dateStr := eventDate(e, opts)
 
-		homeScore, _ := strconv.Atoi(e.IntHomeScore)
-		awayScore, _ := strconv.Atoi(e.IntAwayScore)
-
-		var resultDesc string
-		switch {
-		case homeScore == awayScore:
-			resultDesc = fmt.Sprintf("%s and %s played to a %d-%d draw", e.StrHomeTeam, e.StrAwayTeam, homeScore, awayScore)
-		case homeScore > awayScore:
-			resultDesc = fmt.Sprintf("%s edged out %s with a %d-%d victory", e.StrHomeTeam, e.StrAwayTeam, homeScore, awayScore)
-		default:
-			resultDesc = fmt.Sprintf("%s dominated %s with a %d-%d win", e.StrAwayTeam, e.StrHomeTeam, awayScore, homeScore)
+		title, _, err := uc.writer.MatchReport(defaultReportLanguage, uc.matchFacts(e, defaultReportLanguage, nil))
+		if err != nil {
+			return nil, err
 		}
 
-		headline := fmt.Sprintf("%s on %s | Status: %s", resultDesc, dateStr, e.StrStatus)
+		headline := fmt.Sprintf("%s on %s | Status: %s", title, dateStr, e.StrStatus)
 		news = append(news, headline)
 	}
 
@@ -82,7 +123,7 @@ func (uc *NewsUseCase) GenerateStandingNews() ([]string, error) {
 }
 
 // --- Future Events ---
-func (uc *NewsUseCase) GenerateFutureNews() ([]string, error) {
+func (uc *NewsUseCase) GenerateFutureNews(opts ethiotime.Options) ([]string, error) {
 	events, err := uc.repo.GetFutureEvents()
 	if err != nil {
 		return nil, err
@@ -94,9 +135,10 @@ func (uc *NewsUseCase) GenerateFutureNews() ([]string, error) {
 
 	var news []string
 	for _, e := range events {
-		dateStr := e.DateEvent
-		if parsedDate, err := time.Parse("2006-01-02", e.DateEvent); err == nil {
-			dateStr = parsedDate.Format("02 Jan 2006")
+		dateStr := eventDate(e, opts)
+		// Fans asking for a local calendar or time zone also get the kickoff time
+		if t, ok := eventTime(e); ok && opts.IsSet() {
+			dateStr = opts.FormatDateTime(t)
 		}
 
 		headline := fmt.Sprintf(
@@ -110,14 +152,14 @@ func (uc *NewsUseCase) GenerateFutureNews() ([]string, error) {
 }
 
 // --- Live Scores ---
-func (uc *NewsUseCase) GenerateLiveScores() ([]string, error) {
+func (uc *NewsUseCase) GenerateLiveScores(opts ethiotime.Options) ([]string, error) {
 	events, err := uc.repo.GetLiveScores()
 	if err != nil {
 		return []string{"No live games at the moment. Check back later!"}, nil
 	}
 
 	var news []string
-	today := time.Now().Format("02 Jan 2006")
+	today := opts.FormatDate(time.Now())
 
 	for _, e := range events {
 		headline := fmt.Sprintf(
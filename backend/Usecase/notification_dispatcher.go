@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const (
+	// notificationDedupTTL outlives any match, so a replayed event is
+	// dropped even after long stoppages.
+	notificationDedupTTL = 48 * time.Hour
+	// notificationBuffer holds the events the dispatcher has yet to send
+	// before the live pollers wait for it.
+	notificationBuffer = 256
+	// scheduleCheckInterval is how often the dispatcher looks for leagues
+	// whose matches have started or ended.
+	scheduleCheckInterval = time.Minute
+	// notifyAttempts is how many times a notification is tried, waiting
+	// notifyRetryDelay and then twice as long between attempts.
+	notifyAttempts   = 3
+	notifyRetryDelay = time.Second
+)
+
+// NotificationDispatcher turns live events into notifications for users
+// who follow one of the teams with Notify enabled.
+type NotificationDispatcher struct {
+	hub      ILiveHub
+	follows  domain.IFollowRepo
+	dedup    domain.IDedupRepo
+	notifier domain.Notifier
+	leagues  domain.ILeagueRegistry
+	schedule domain.IJobRepo
+	now      func() time.Time
+}
+
+func NewNotificationDispatcher(
+	hub ILiveHub,
+	follows domain.IFollowRepo,
+	dedup domain.IDedupRepo,
+	notifier domain.Notifier,
+	leagues domain.ILeagueRegistry,
+	schedule domain.IJobRepo,
+) *NotificationDispatcher {
+	return &NotificationDispatcher{
+		hub:      hub,
+		follows:  follows,
+		dedup:    dedup,
+		notifier: notifier,
+		leagues:  leagues,
+		schedule: schedule,
+		now:      time.Now,
+	}
+}
+
+// Run listens to the live feed of each league while one of its matches is
+// in play, going by the kickoff times the ingestion worker saves, and
+// blocks until ctx is cancelled. Leagues with nothing in play cost no live
+// polling.
+func (d *NotificationDispatcher) Run(ctx context.Context) {
+	events := make(chan domain.LiveEvent, notificationBuffer)
+	listening := map[string]func(){}
+	defer func() {
+		for _, stop := range listening {
+			stop()
+		}
+	}()
+
+	ticker := time.NewTicker(scheduleCheckInterval)
+	defer ticker.Stop()
+	d.listen(ctx, listening, events)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.listen(ctx, listening, events)
+		case e := <-events:
+			if err := d.Handle(ctx, e); err != nil {
+				fmt.Printf("notification dispatch failed (league=%s, fixture=%d): %v\n", e.League, e.FixtureID, err)
+			}
+		}
+	}
+}
+
+// listen starts listening to the leagues whose kickoff window is open and
+// stops listening to those whose window has closed.
+func (d *NotificationDispatcher) listen(ctx context.Context, listening map[string]func(), events chan<- domain.LiveEvent) {
+	now := d.now()
+	for _, league := range d.leagues.All() {
+		kickoffs, err := d.schedule.Kickoffs(ctx, league.Code)
+		if err != nil {
+			fmt.Printf("could not read kickoff times (league=%s): %v\n", league.Code, err)
+			continue
+		}
+
+		stop, on := listening[league.Code]
+		switch live := inKickoffWindow(kickoffs, now); {
+		case live && !on:
+			listening[league.Code] = d.hub.Listen(league.Code, events)
+		case !live && on:
+			stop()
+			delete(listening, league.Code)
+		}
+	}
+}
+
+// Handle sends the notifications for a single live event. Each follower
+// is sent an event once, however many polls or replicas report it; a send
+// that fails is retried with backoff.
+func (d *NotificationDispatcher) Handle(ctx context.Context, e domain.LiveEvent) error {
+	if e.Fixture == nil {
+		return nil
+	}
+
+	key := ""
+	switch e.Type {
+	case domain.LiveEventGoal:
+		// a goal is the scoring team's nth, whichever poll or replica sees it
+		key = fmt.Sprintf("%d:goal:%d:%d", e.FixtureID, e.TeamID, goalNumber(e))
+	case domain.LiveEventKickoff, domain.LiveEventFullTime:
+		key = fmt.Sprintf("%d:%s", e.FixtureID, e.Type)
+	default:
+		return nil
+	}
+
+	for _, side := range []domain.MTeam{e.Fixture.HomeTeam, e.Fixture.AwayTeam} {
+		if side.ID == 0 {
+			continue
+		}
+		kind, title, body := describe(e, side)
+		if err := d.notifyFollowers(ctx, side, e, key, kind, title, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *NotificationDispatcher) notifyFollowers(ctx context.Context, team domain.MTeam, e domain.LiveEvent, key, kind, title, body string) error {
+	teamID := strconv.Itoa(team.ID)
+	users, err := d.follows.Followers(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, user := range users {
+		follow, err := d.follows.Get(ctx, user, teamID)
+		if err != nil || !follow.Notify {
+			continue
+		}
+
+		sent := fmt.Sprintf("%s:%s:%s", key, teamID, user)
+		first, err := d.dedup.FirstSeen(ctx, sent, notificationDedupTTL)
+		if err != nil {
+			return err
+		}
+		if !first {
+			continue
+		}
+
+		n := domain.Notification{
+			UserID:    user,
+			TeamID:    teamID,
+			FixtureID: e.FixtureID,
+			Type:      kind,
+			Title:     title,
+			Body:      body,
+			Fixture:   e.Fixture,
+			CreatedAt: now,
+		}
+		if err := d.send(ctx, n); err != nil {
+			fmt.Printf("notify user %s failed: %v\n", user, err)
+			// unmark it so a replica yet to handle the event can still send it
+			if err := d.dedup.Forget(ctx, sent); err != nil {
+				fmt.Printf("could not unmark notification %s: %v\n", sent, err)
+			}
+		}
+	}
+	return nil
+}
+
+// send delivers n, retrying failed attempts with backoff. It returns the
+// last error once every attempt has failed.
+func (d *NotificationDispatcher) send(ctx context.Context, n domain.Notification) error {
+	delay := notifyRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := d.notifier.Notify(ctx, n)
+		if err == nil || attempt == notifyAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// goalNumber counts the goals of the scoring team, from the score after
+// the goal.
+func goalNumber(e domain.LiveEvent) int {
+	var home, away int
+	fmt.Sscanf(e.Score, "%d-%d", &home, &away)
+	if e.Fixture.AwayTeam.ID == e.TeamID {
+		return away
+	}
+	return home
+}
+
+// describe builds the notification type and text from the point of view of team.
+func describe(e domain.LiveEvent, team domain.MTeam) (kind, title, body string) {
+	f := e.Fixture
+	score := fmt.Sprintf("%s %d-%d %s", f.HomeTeam.Name, goalsOf(f.Goals.Home), goalsOf(f.Goals.Away), f.AwayTeam.Name)
+
+	switch e.Type {
+	case domain.LiveEventGoal:
+		if e.Score != "" {
+			score = fmt.Sprintf("%s %s %s", f.HomeTeam.Name, e.Score, f.AwayTeam.Name)
+		}
+		minute := ""
+		if f.Status.Elapsed > 0 {
+			minute = fmt.Sprintf(" (%d')", f.Status.Elapsed)
+		}
+		if e.TeamID == team.ID {
+			return domain.NotifyGoalScored, "GOAL! " + score, fmt.Sprintf("%s scored%s.", team.Name, minute)
+		}
+		return domain.NotifyGoalConceded, "Goal conceded: " + score, fmt.Sprintf("%s conceded to %s%s.", team.Name, e.Team, minute)
+	case domain.LiveEventKickoff:
+		return domain.NotifyKickoff, "Kick-off: " + f.HomeTeam.Name + " vs " + f.AwayTeam.Name, fmt.Sprintf("%s are under way.", team.Name)
+	default:
+		return domain.NotifyFullTime, "Full time: " + score, fmt.Sprintf("Final score for %s.", team.Name)
+	}
+}
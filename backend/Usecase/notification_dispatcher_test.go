@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func goalEvent(score string, minute int) domain.LiveEvent {
+	home, away := 1, 0
+	return domain.LiveEvent{
+		Type:      domain.LiveEventGoal,
+		League:    "ETH",
+		FixtureID: 7,
+		Team:      "Saint George",
+		TeamID:    1,
+		Score:     score,
+		Fixture: &domain.PrevFixtures{
+			FixtureID: 7,
+			HomeTeam:  domain.MTeam{ID: 1, Name: "Saint George"},
+			AwayTeam:  domain.MTeam{ID: 2, Name: "Fasil Ketema"},
+			Goals:     domain.Goals{Home: &home, Away: &away},
+			Status:    domain.Status{Short: "1H", Elapsed: minute},
+		},
+	}
+}
+
+func newDispatcher(notifier *recordingNotifier, follows map[string][]string) *usecase.NotificationDispatcher {
+	return usecase.NewNotificationDispatcher(nil, memoryFollowRepo(follows), &memoryDedup{seen: map[string]bool{}}, notifier, nil, nil)
+}
+
+func TestDispatcherRetriesFailedNotifications(t *testing.T) {
+	notifier := &recordingNotifier{fail: map[string]int{"bob": 1}}
+	d := newDispatcher(notifier, map[string][]string{"1": {"alice", "bob"}})
+
+	if err := d.Handle(context.Background(), goalEvent("1-0", 23)); err != nil {
+		t.Fatal(err)
+	}
+	if got := notifier.sentTo(); fmt.Sprint(got) != "[alice bob]" {
+		t.Errorf("sent to %v, want bob's failed send retried", got)
+	}
+}
+
+func TestDispatcherNotifiesEachTeamAndGoal(t *testing.T) {
+	notifier := &recordingNotifier{}
+	d := newDispatcher(notifier, map[string][]string{"1": {"alice"}, "2": {"alice"}})
+
+	if err := d.Handle(context.Background(), goalEvent("1-0", 23)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != 2 || notifier.sent[0].Type != domain.NotifyGoalScored || notifier.sent[1].Type != domain.NotifyGoalConceded {
+		t.Fatalf("a follower of both teams got %+v, want a scored and a conceded notification", notifier.sent)
+	}
+
+	// the next poll, or another replica, sees the same goal a minute later
+	if err := d.Handle(context.Background(), goalEvent("1-0", 24)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != 2 {
+		t.Fatalf("%d notifications after the goal was seen again, want 2", len(notifier.sent))
+	}
+
+	// the team's second goal
+	if err := d.Handle(context.Background(), goalEvent("2-0", 61)); err != nil {
+		t.Fatal(err)
+	}
+	if len(notifier.sent) != 4 {
+		t.Errorf("%d notifications after the second goal, want 4", len(notifier.sent))
+	}
+}
+
+// recordingNotifier records the notifications sent, failing the first
+// fail[user] sends to a user.
+type recordingNotifier struct {
+	mu   sync.Mutex
+	fail map[string]int
+	sent []domain.Notification
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.fail[notification.UserID] > 0 {
+		n.fail[notification.UserID]--
+		return errors.New("unreachable")
+	}
+	n.sent = append(n.sent, notification)
+	return nil
+}
+
+func (n *recordingNotifier) sentTo() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var users []string
+	for _, s := range n.sent {
+		users = append(users, s.UserID)
+	}
+	return users
+}
+
+// memoryFollowRepo maps team ids to the users who follow them with Notify on.
+type memoryFollowRepo map[string][]string
+
+func (m memoryFollowRepo) Follow(ctx context.Context, userID string, follow domain.FollowedTeam) error {
+	return nil
+}
+
+func (m memoryFollowRepo) Unfollow(ctx context.Context, userID, teamID string) error {
+	return nil
+}
+
+func (m memoryFollowRepo) List(ctx context.Context, userID string) ([]domain.FollowedTeam, error) {
+	return nil, nil
+}
+
+func (m memoryFollowRepo) Get(ctx context.Context, userID, teamID string) (*domain.FollowedTeam, error) {
+	return &domain.FollowedTeam{TeamID: teamID, Notify: true}, nil
+}
+
+func (m memoryFollowRepo) Followers(ctx context.Context, teamID string) ([]string, error) {
+	return m[teamID], nil
+}
+
+type memoryDedup struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func (m *memoryDedup) FirstSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.seen[key] {
+		return false, nil
+	}
+	m.seen[key] = true
+	return true, nil
+}
+
+func (m *memoryDedup) Forget(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.seen, key)
+	return nil
+}
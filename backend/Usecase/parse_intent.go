@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"log"
+
 	"github.com/abrshodin/ethio-fb-backend/Domain"
 )
 
@@ -24,3 +26,36 @@ func (uc *ParseIntentUseCase) Execute(text string) (*domain.Intent, error) {
 	}
 	return uc.parser.Parse(text)
 }
+
+// LayeredIntentParser combines the rule parser with an LLM parser. With
+// rulesFirst, clear queries are answered by the rules without calling the
+// LLM; in either mode the rules' best guess is used when the LLM fails.
+type LayeredIntentParser struct {
+	rules      *RuleIntentParser
+	llm        IntentParser
+	rulesFirst bool
+}
+
+func NewLayeredIntentParser(rules *RuleIntentParser, llm IntentParser, rulesFirst bool) *LayeredIntentParser {
+	return &LayeredIntentParser{rules: rules, llm: llm, rulesFirst: rulesFirst}
+}
+
+func (p *LayeredIntentParser) Parse(text string) (*domain.Intent, error) {
+	intent, clear := p.rules.Match(text)
+	if p.rulesFirst && clear {
+		return intent, nil
+	}
+
+	if p.llm != nil {
+		parsed, err := p.llm.Parse(text)
+		if err == nil {
+			return parsed, nil
+		}
+		log.Printf("llm intent parser failed, using rules: %v", err)
+	}
+
+	if intent.Topic == "" {
+		return nil, ErrIntentNotFound
+	}
+	return intent, nil
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	repository "github.com/abrshodin/ethio-fb-backend/Repository"
+)
+
+var (
+	// Squads change in transfer windows, not between matchdays.
+	squadPolicy = repository.CachePolicy{FreshFor: 24 * time.Hour, StaleFor: 30 * 24 * time.Hour}
+	// Player statistics move with every matchday.
+	playerPolicy = repository.CachePolicy{FreshFor: 12 * time.Hour, StaleFor: 30 * 24 * time.Hour}
+	// The scoring chart moves with every matchday.
+	topScorersPolicy = repository.CachePolicy{FreshFor: 6 * time.Hour, StaleFor: 30 * 24 * time.Hour}
+)
+
+type IPlayerUsecase interface {
+	Squad(ctx context.Context, teamID, season int) (*domain.Squad, error)
+	Player(ctx context.Context, playerID, season int) (*domain.PlayerProfile, error)
+	TopScorers(ctx context.Context, league string, season int) ([]domain.TopScorer, error)
+}
+
+type PlayerUsecase struct {
+	api     domain.IAPIService
+	cache   *repository.SWRCache
+	leagues domain.ILeagueRegistry
+}
+
+func NewPlayerUsecase(api domain.IAPIService, cache *repository.SWRCache, leagues domain.ILeagueRegistry) IPlayerUsecase {
+	return &PlayerUsecase{api: api, cache: cache, leagues: leagues}
+}
+
+// Squad returns a team's squad. Upstream only knows the current squad, so
+// season just keeps squads of past seasons apart in the cache.
+func (uc *PlayerUsecase) Squad(ctx context.Context, teamID, season int) (*domain.Squad, error) {
+	key := fmt.Sprintf("squad:%d:%d", teamID, season)
+	return repository.Fetch(ctx, uc.cache, key, squadPolicy, func(ctx context.Context) (*domain.Squad, error) {
+		return uc.api.Squad(ctx, teamID)
+	})
+}
+
+func (uc *PlayerUsecase) Player(ctx context.Context, playerID, season int) (*domain.PlayerProfile, error) {
+	key := fmt.Sprintf("player:%d:%d", playerID, season)
+	return repository.Fetch(ctx, uc.cache, key, playerPolicy, func(ctx context.Context) (*domain.PlayerProfile, error) {
+		return uc.api.Player(ctx, playerID, season)
+	})
+}
+
+func (uc *PlayerUsecase) TopScorers(ctx context.Context, league string, season int) ([]domain.TopScorer, error) {
+	l, err := uc.leagues.Resolve(league)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("topscorers:%s:%d", l.Code, season)
+	return repository.Fetch(ctx, uc.cache, key, topScorersPolicy, func(ctx context.Context) ([]domain.TopScorer, error) {
+		return uc.api.TopScorers(ctx, l.APISportsID, season)
+	})
+}
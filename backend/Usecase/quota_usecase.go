@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+type IQuotaUsecase interface {
+	Budgets(ctx context.Context) ([]domain.QuotaBudget, error)
+}
+
+type QuotaUsecase struct {
+	quota     domain.IQuotaRepo
+	providers []string
+}
+
+func NewQuotaUsecase(quota domain.IQuotaRepo, providers ...string) IQuotaUsecase {
+	return &QuotaUsecase{quota: quota, providers: providers}
+}
+
+func (uc *QuotaUsecase) Budgets(ctx context.Context) ([]domain.QuotaBudget, error) {
+	budgets := make([]domain.QuotaBudget, 0, len(uc.providers))
+	for _, p := range uc.providers {
+		b, err := uc.quota.GetBudget(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, *b)
+	}
+	return budgets, nil
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// defaultReportLanguage fills in phrases another language set lacks.
+const defaultReportLanguage = "en"
+
+// ReportWriter renders news phrasing from per-language template sets, so
+// stories read naturally in every supported language without an LLM call.
+type ReportWriter struct {
+	sets map[string]map[string]*template.Template
+}
+
+// NewReportWriter parses templates. It needs at least the English set.
+func NewReportWriter(templates domain.ReportTemplates) (*ReportWriter, error) {
+	if _, ok := templates[defaultReportLanguage]; !ok {
+		return nil, fmt.Errorf("report templates: no %q set", defaultReportLanguage)
+	}
+
+	w := &ReportWriter{sets: map[string]map[string]*template.Template{}}
+	for lang, phrases := range templates {
+		w.sets[lang] = map[string]*template.Template{}
+		for key, text := range phrases {
+			t, err := template.New(lang + "." + key).Funcs(template.FuncMap{"ordinal": ordinal}).Parse(text)
+			if err != nil {
+				return nil, fmt.Errorf("report template %s.%s: %w", lang, key, err)
+			}
+			w.sets[lang][key] = t
+		}
+	}
+	return w, nil
+}
+
+// Languages returns the languages with a template set, English first.
+func (w *ReportWriter) Languages() []string {
+	langs := []string{defaultReportLanguage}
+	for lang := range w.sets {
+		if lang != defaultReportLanguage {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs[1:])
+	return langs
+}
+
+// Render fills the phrase key in lang with data. The first of keys that
+// lang has is used; when it has none, English is tried the same way.
+func (w *ReportWriter) Render(lang string, data any, keys ...string) (string, error) {
+	for _, l := range []string{lang, defaultReportLanguage} {
+		for _, key := range keys {
+			t, ok := w.sets[l][key]
+			if !ok {
+				continue
+			}
+			var b strings.Builder
+			if err := t.Execute(&b, data); err != nil {
+				return "", err
+			}
+			return b.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no report template for %v", keys)
+}
+
+type matchView struct {
+	domain.MatchFacts
+	Winner      string
+	Loser       string
+	WinnerGoals int
+	LoserGoals  int
+	Margin      int
+	WinnerRank  int
+	LoserRank   int
+	Places      int // places the winner climbed
+}
+
+// MatchReport writes the headline and summary of a finished match. The
+// phrasing follows the margin, a comeback from behind at half time, the
+// derby flag and what the result did to the table.
+func (w *ReportWriter) MatchReport(lang string, f domain.MatchFacts) (string, string, error) {
+	v := matchView{MatchFacts: f}
+
+	var keys []string
+	switch {
+	case f.HomeGoals == f.AwayGoals && f.HomeGoals == 0:
+		keys = []string{"goalless_draw", "draw"}
+	case f.HomeGoals == f.AwayGoals:
+		keys = []string{"draw"}
+	default:
+		v.Winner, v.Loser, v.WinnerGoals, v.LoserGoals = f.Home, f.Away, f.HomeGoals, f.AwayGoals
+		v.WinnerRank, v.LoserRank = f.HomeRankAfter, f.AwayRankAfter
+		winnerBefore, loserBefore := f.HomeRankBefore, f.AwayRankBefore
+		trailed := f.HalftimeHome != nil && f.HalftimeAway != nil && *f.HalftimeHome < *f.HalftimeAway
+		if f.AwayGoals > f.HomeGoals {
+			v.Winner, v.Loser, v.WinnerGoals, v.LoserGoals = f.Away, f.Home, f.AwayGoals, f.HomeGoals
+			v.WinnerRank, v.LoserRank = f.AwayRankAfter, f.HomeRankAfter
+			winnerBefore, loserBefore = f.AwayRankBefore, f.HomeRankBefore
+			trailed = f.HalftimeHome != nil && f.HalftimeAway != nil && *f.HalftimeAway < *f.HalftimeHome
+		}
+		v.Margin = v.WinnerGoals - v.LoserGoals
+
+		switch {
+		case trailed:
+			keys = []string{"comeback_win", "win"}
+		case v.Margin >= 3:
+			keys = []string{"rout", "win"}
+		case v.Margin == 1:
+			keys = []string{"narrow_win", "win"}
+		default:
+			keys = []string{"win"}
+		}
+
+		// a table move is only reported when both positions are known
+		if winnerBefore == 0 || v.WinnerRank == 0 {
+			v.WinnerRank = 0
+		} else {
+			v.Places = winnerBefore - v.WinnerRank
+		}
+		if loserBefore == 0 || v.LoserRank <= loserBefore {
+			v.LoserRank = 0
+		}
+	}
+	if f.Derby {
+		derby := "derby_win"
+		if f.HomeGoals == f.AwayGoals {
+			derby = "derby_draw"
+		}
+		keys = append([]string{"derby_" + keys[0], derby}, keys...)
+	}
+
+	title, err := w.Render(lang, v, keys...)
+	if err != nil {
+		return "", "", err
+	}
+	snippet, err := w.Render(lang, v, "result")
+	if err != nil {
+		return "", "", err
+	}
+
+	var impacts []string
+	switch {
+	case v.WinnerRank == 1 && v.Places == 0:
+		impacts = append(impacts, "impact_stay_top")
+	case v.WinnerRank == 1:
+		impacts = append(impacts, "impact_top")
+	case v.Places > 0:
+		impacts = append(impacts, "impact_climb")
+	}
+	if v.LoserRank > 0 {
+		impacts = append(impacts, "impact_drop")
+	}
+	for _, key := range impacts {
+		sentence, err := w.Render(lang, v, key)
+		if err != nil {
+			return "", "", err
+		}
+		snippet += " " + sentence
+	}
+	return title, snippet, nil
+}
+
+// ordinal renders 1 as "1st", 2 as "2nd" and so on.
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
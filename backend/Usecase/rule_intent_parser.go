@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+)
+
+// topicKeywords holds English, Amharic (Ge'ez) and transliterated Amharic
+// keywords per intent topic.
+var topicKeywords = map[string][]string{
+	"table": {
+		"table", "standings", "standing", "ranking", "rankings", "position", "points table", "log",
+		"ደረጃ", "ሰንጠረዥ", "የደረጃ ሰንጠረዥ",
+		"dereja", "senterej", "sentereju",
+	},
+	"fixture": {
+		"fixture", "fixtures", "schedule", "next match", "next game", "when", "kickoff", "kick off",
+		"result", "results", "score", "scores", "play", "playing", "match", "game",
+		"ጨዋታ", "ጨዋታው", "ውጤት", "መርሃ ግብር", "መቼ", "ይጫወታል", "ይጫወታሉ",
+		"chewata", "chewataw", "wutet", "mernagibr", "meche", "yechawetal",
+	},
+	"compare": {
+		"compare", "comparison", "vs", "versus", "better", "head to head", "head-to-head", "h2h",
+		"ማወዳደር", "አወዳድር", "ንጽጽር", "ማነው የሚሻለው", "ይሻላል",
+		"awedadir", "nitsitsir", "yishalal",
+	},
+	"news": {
+		"news", "latest", "headlines", "update", "updates", "what happened",
+		"ዜና", "ዜናዎች", "አዲስ ነገር",
+		"zena", "zenawoch",
+	},
+	"player": {
+		"top scorer", "top scorers", "scorer", "scorers", "scoring chart", "golden boot", "assists",
+		"player", "players", "squad", "yellow cards", "red cards",
+		"ግብ አግቢ", "ኮከብ ግብ አግቢ", "ተጫዋች", "ተጫዋቾች", "ቡድን ስብስብ",
+		"gib agbi", "techawach", "techawachoch",
+	},
+	"fact": {
+		"history", "founded", "fact", "facts", "stadium", "who is", "bio", "about",
+		"ታሪክ", "ተመሰረተ", "ስታዲየም",
+		"tarik", "temeserete",
+	},
+}
+
+// topicOrder breaks ties when a query matches several topics.
+var topicOrder = []string{"compare", "player", "table", "news", "fixture", "fact"}
+
+// relativeDays maps day words to an offset from today.
+var relativeDays = []struct {
+	words  []string
+	offset int
+}{
+	{[]string{"today", "tonight", "ዛሬ", "zare"}, 0},
+	{[]string{"tomorrow", "ነገ", "nege"}, 1},
+	{[]string{"yesterday", "ትናንት", "ትላንት", "tinant", "tilant"}, -1},
+}
+
+var weekendWords = []string{"weekend", "this weekend", "saturday", "ቅዳሜ", "ቅዳሜና እሁድ", "kidame"}
+
+// transliterated Amharic words that mark the query as Amharic even in Latin script
+var amharicLatinWords = []string{
+	"dereja", "senterej", "chewata", "wutet", "zena", "tarik", "meche", "zare", "nege", "tinant",
+	"kidame", "awedadir", "yechawetal", "yishalal", "ityopia", "engliz", "agbi", "techawach",
+}
+
+// followUpCues open a follow-up question; they carry no topic of their own
+// ("what about" is not a request for facts).
+var followUpCues = []string{"what about", "how about", "and what about", "ስለ", "sile"}
+
+var isoDate = regexp.MustCompile(`\b(\d{4}-\d{2}-\d{2})\b`)
+
+// Amharic attaches prepositions and case endings to nouns ("የቅዱስ ጊዮርጊስ",
+// "ቡናን"), so Ge'ez aliases are also matched with these affixes.
+var (
+	geezPrefixes = []string{"", "የ", "ለ", "በ", "ከ", "እና"}
+	geezSuffixes = []string{"", "ን", "ም", "ና", "ስ", "ው", "ዎች"}
+)
+
+// RuleIntentParser detects intents from keyword and alias dictionaries. It
+// needs no network, so it keeps /intent/parse working when the LLM is down
+// and answers clear queries like "EPL table" without calling the LLM.
+type RuleIntentParser struct {
+	teams   domain.ITeamDirectory
+	leagues domain.ILeagueRegistry
+	now     func() time.Time
+}
+
+func NewRuleIntentParser(teams domain.ITeamDirectory, leagues domain.ILeagueRegistry) *RuleIntentParser {
+	return &RuleIntentParser{teams: teams, leagues: leagues, now: time.Now}
+}
+
+// Parse implements IntentParser.
+func (p *RuleIntentParser) Parse(text string) (*domain.Intent, error) {
+	intent, _ := p.Match(text)
+	if intent.Topic == "" {
+		return nil, ErrIntentNotFound
+	}
+	return intent, nil
+}
+
+// Match returns the detected intent and whether it is clear: exactly one
+// topic matched by keyword. The intent's Topic is empty when nothing matched.
+func (p *RuleIntentParser) Match(text string) (*domain.Intent, bool) {
+	intent, topics := p.scan(text)
+
+	if intent.League == "" {
+		intent.League = p.leagues.Default().Code
+		if len(intent.Teams) > 0 {
+			intent.League = p.teamLeague(intent.Teams[0])
+		}
+	}
+
+	switch {
+	case intent.Topic != "":
+	case len(intent.Teams) >= 2:
+		intent.Topic = "compare"
+	case len(intent.Teams) == 1:
+		intent.Topic = "fact"
+	}
+
+	clear := len(topics) == 1 && (intent.Topic != "compare" || len(intent.Teams) >= 2)
+	return intent, clear
+}
+
+// Mentions returns only the slots text states outright: a topic keyword,
+// team names, a league and a date. Slots the text leaves out are empty, so
+// a follow-up like "what about Arsenal?" can inherit them.
+func (p *RuleIntentParser) Mentions(text string) *domain.Intent {
+	intent, _ := p.scan(text)
+	return intent
+}
+
+// scan extracts the explicit slots of text and the topics whose keywords it
+// contains, in topicOrder.
+func (p *RuleIntentParser) scan(text string) (*domain.Intent, []string) {
+	norm := normalizeQuery(text)
+	for _, cue := range followUpCues {
+		for at, length := findAlias(norm, cue); at >= 0; at, length = findAlias(norm, cue) {
+			norm = norm[:at] + norm[at+length:]
+		}
+	}
+
+	intent := &domain.Intent{Teams: []string{}, Language: "english"}
+	if containsGeez(text) || matchesAny(norm, amharicLatinWords) {
+		intent.Language = "amharic"
+	}
+
+	for _, team := range matchTeams(norm, p.teams.Teams("")) {
+		intent.Teams = append(intent.Teams, team.Name)
+	}
+	intent.League = p.matchLeague(norm)
+	intent.Date = p.matchDate(norm)
+
+	var topics []string
+	for _, topic := range topicOrder {
+		if matchesAny(norm, topicKeywords[topic]) {
+			topics = append(topics, topic)
+		}
+	}
+	if len(topics) > 0 {
+		intent.Topic = topics[0]
+		// "Arsenal vs Chelsea" is a comparison even without "compare"
+		if len(intent.Teams) >= 2 && slices.Contains(topics, "compare") {
+			intent.Topic = "compare"
+		}
+	}
+	return intent, topics
+}
+
+func (p *RuleIntentParser) teamLeague(name string) string {
+	for _, t := range p.teams.Teams("") {
+		if t.Name == name {
+			return t.League
+		}
+	}
+	return p.leagues.Default().Code
+}
+
+func (p *RuleIntentParser) matchDate(norm string) string {
+	if m := isoDate.FindString(norm); m != "" {
+		return m
+	}
+
+	today := p.now().In(ethiotime.EAT)
+	for _, d := range relativeDays {
+		if matchesAny(norm, d.words) {
+			return today.AddDate(0, 0, d.offset).Format("2006-01-02")
+		}
+	}
+	if matchesAny(norm, weekendWords) {
+		days := (int(time.Saturday) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, days).Format("2006-01-02")
+	}
+	return ""
+}
+
+// matchTeams returns the teams mentioned in norm in the order they appear.
+// Longer aliases win, so "manchester city" is not read as "city" twice.
+func matchTeams(norm string, known []domain.TeamAlias) []domain.TeamAlias {
+	type hit struct {
+		team domain.TeamAlias
+		at   int
+	}
+	var hits []hit
+	taken := make([]bool, len(norm))
+
+	type alias struct {
+		team domain.TeamAlias
+		text string
+	}
+	var aliases []alias
+	for _, t := range known {
+		aliases = append(aliases, alias{t, t.Name})
+		for _, a := range t.Aliases {
+			aliases = append(aliases, alias{t, a})
+		}
+	}
+	slices.SortStableFunc(aliases, func(a, b alias) int { return len(b.text) - len(a.text) })
+
+	seen := map[string]bool{}
+	for _, a := range aliases {
+		at, length := findAlias(norm, a.text)
+		if at < 0 || taken[at] {
+			continue
+		}
+		for i := at; i < at+length; i++ {
+			taken[i] = true
+		}
+		if seen[a.team.Name] {
+			continue
+		}
+		seen[a.team.Name] = true
+		hits = append(hits, hit{a.team, at})
+	}
+
+	slices.SortFunc(hits, func(a, b hit) int { return a.at - b.at })
+	teams := make([]domain.TeamAlias, 0, len(hits))
+	for _, h := range hits {
+		teams = append(teams, h.team)
+	}
+	return teams
+}
+
+// matchLeague returns the code of the league whose code, name or alias is
+// the longest match in norm.
+func (p *RuleIntentParser) matchLeague(norm string) string {
+	best, bestLen := "", 0
+	for _, league := range p.leagues.All() {
+		aliases := append([]string{league.Code}, league.Aliases...)
+		for _, name := range league.Names {
+			aliases = append(aliases, name)
+		}
+		for _, a := range aliases {
+			if at, _ := findAlias(norm, a); at >= 0 && len(a) > bestLen {
+				best, bestLen = league.Code, len(a)
+			}
+		}
+	}
+	return best
+}
+
+func matchesAny(norm string, words []string) bool {
+	for _, w := range words {
+		if at, _ := findAlias(norm, w); at >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// findAlias looks for alias as whole words in norm, allowing Amharic
+// affixes on Ge'ez aliases. It returns the byte offset and length of the
+// match, or -1.
+func findAlias(norm, alias string) (int, int) {
+	alias = normalizeQuery(alias)
+	if alias == " " {
+		return -1, 0
+	}
+
+	prefixes, suffixes := []string{""}, []string{""}
+	if containsGeez(alias) {
+		prefixes, suffixes = geezPrefixes, geezSuffixes
+	}
+
+	word := strings.TrimSpace(alias)
+	for _, pre := range prefixes {
+		for _, suf := range suffixes {
+			needle := " " + pre + word + suf + " "
+			if i := strings.Index(norm, needle); i >= 0 {
+				return i + 1, len(needle) - 2
+			}
+		}
+	}
+	return -1, 0
+}
+
+// normalizeQuery lowercases text, turns punctuation (including Ethiopic
+// punctuation) into spaces and pads the result with single spaces.
+func normalizeQuery(text string) string {
+	var b strings.Builder
+	b.WriteByte(' ')
+	space := true
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r) || r == '-' {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	if !space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+func containsGeez(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Ethiopic, r) {
+			return true
+		}
+	}
+	return false
+}
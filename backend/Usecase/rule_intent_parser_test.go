@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	ethiotime "github.com/abrshodin/ethio-fb-backend/EthioTime"
+	infrastructure "github.com/abrshodin/ethio-fb-backend/Infrastructure"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func TestRuleParserFindsConfiguredLeagues(t *testing.T) {
+	_, teams := loadConfig(t)
+	leagues, err := infrastructure.NewLeagueRegistry([]domain.LeagueConfig{
+		{Code: "EPL", APISportsID: 39, Names: map[string]string{"en": "English Premier League"}, Aliases: []string{"premier league"}},
+		{Code: "LL", APISportsID: 140, Names: map[string]string{"en": "La Liga"}, Aliases: []string{"spanish league"}, Default: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules := usecase.NewRuleIntentParser(teams, leagues)
+
+	for text, want := range map[string]string{
+		"la liga table":               "LL",
+		"spanish league table":        "LL",
+		"LL table":                    "LL",
+		"premier league table":        "EPL",
+		"english premier league news": "EPL",
+		"league table":                "LL",
+	} {
+		intent, _ := rules.Match(text)
+		if intent.League != want {
+			t.Errorf("%q: league = %q, want %q", text, intent.League, want)
+		}
+	}
+}
+
+func TestLeagueRegistryRejectsSharedAPISportsID(t *testing.T) {
+	_, err := infrastructure.NewLeagueRegistry([]domain.LeagueConfig{
+		{Code: "ETH", APISportsID: 363},
+		{Code: "ETH2", APISportsID: 363},
+	})
+	if err == nil {
+		t.Error("two leagues with one api_sports_id were accepted")
+	}
+}
+
+func TestRuleParserReadsEnglishAndAmharic(t *testing.T) {
+	leagues, teams := loadConfig(t)
+	rules := usecase.NewRuleIntentParser(teams, leagues)
+	tomorrow := time.Now().In(ethiotime.EAT).AddDate(0, 0, 1).Format("2006-01-02")
+
+	for _, tc := range []struct {
+		text  string
+		want  domain.Intent
+		clear bool
+	}{
+		{"EPL table", domain.Intent{Topic: "table", Teams: []string{}, League: "EPL", Language: "english"}, true},
+		{"Arsenal vs Chelsea", domain.Intent{Topic: "compare", Teams: []string{"Arsenal", "Chelsea"}, League: "EPL", Language: "english"}, true},
+		{"የቅዱስ ጊዮርጊስ ጨዋታ ነገ", domain.Intent{Topic: "fixture", Teams: []string{"Kedus Giorgis"}, League: "ETH", Date: tomorrow, Language: "amharic"}, true},
+		{"ethiopia bunna chewata 2024-03-02", domain.Intent{Topic: "fixture", Teams: []string{"Ethiopia Bunna"}, League: "ETH", Date: "2024-03-02", Language: "amharic"}, true},
+		{"Manchester City", domain.Intent{Topic: "fact", Teams: []string{"Manchester City"}, League: "EPL", Language: "english"}, false},
+		{"latest news and table", domain.Intent{Topic: "table", Teams: []string{}, League: "ETH", Language: "english"}, false},
+	} {
+		got, clear := rules.Match(tc.text)
+		if got.Topic != tc.want.Topic || !slices.Equal(got.Teams, tc.want.Teams) || got.League != tc.want.League ||
+			got.Date != tc.want.Date || got.Language != tc.want.Language {
+			t.Errorf("Match(%q) = %+v, want %+v", tc.text, *got, tc.want)
+		}
+		if clear != tc.clear {
+			t.Errorf("Match(%q) clear = %v, want %v", tc.text, clear, tc.clear)
+		}
+	}
+
+	if _, err := rules.Parse("hello there"); err != usecase.ErrIntentNotFound {
+		t.Errorf("Parse without any keyword: err = %v, want ErrIntentNotFound", err)
+	}
+}
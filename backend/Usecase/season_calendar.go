@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	repository "github.com/abrshodin/ethio-fb-backend/Repository"
+)
+
+// currentSeasonTTL bounds how long a discovered season is trusted before
+// the calendar looks at fixture data again.
+const currentSeasonTTL = 12 * time.Hour
+
+type ISeasonCalendar interface {
+	CurrentSeason(ctx context.Context, league string) (int, error)
+	// HasSeason reports whether season is one of the league's configured
+	// seasons or the season the calendar finds running.
+	HasSeason(ctx context.Context, league string, season int) (bool, error)
+	RoundWindow(ctx context.Context, league string, season int, round string) (domain.RoundQuery, error)
+	SeasonWindow(ctx context.Context, league string, season int) (domain.RoundQuery, error)
+}
+
+// SeasonCalendar works out seasons and round windows from fixture data and
+// keeps what it learns in Redis.
+type SeasonCalendar struct {
+	api      domain.IAPIService
+	fixtures repository.IFixturesRepo
+	seasons  domain.ISeasonRepo
+	leagues  domain.ILeagueRegistry
+	now      func() time.Time
+}
+
+func NewSeasonCalendar(api domain.IAPIService, fixtures repository.IFixturesRepo, seasons domain.ISeasonRepo, leagues domain.ILeagueRegistry) *SeasonCalendar {
+	return &SeasonCalendar{
+		api:      api,
+		fixtures: fixtures,
+		seasons:  seasons,
+		leagues:  leagues,
+		now:      time.Now,
+	}
+}
+
+// CurrentSeason returns the most recent season of the league that has
+// fixture data. Candidates start at the season the calendar config says is
+// running today and walk back through the configured seasons.
+func (sc *SeasonCalendar) CurrentSeason(ctx context.Context, league string) (int, error) {
+	l, err := sc.leagues.ByCode(league)
+	if err != nil {
+		return 0, err
+	}
+
+	if season, err := sc.seasons.GetCurrentSeason(ctx, l.Code); err == nil {
+		return season, nil
+	}
+
+	for _, season := range sc.candidateSeasons(l) {
+		found, err := sc.discover(ctx, l, season)
+		if err != nil {
+			fmt.Printf("season discovery failed (league=%s, season=%d): %v\n", l.Code, season, err)
+			continue
+		}
+		if found {
+			_ = sc.seasons.SaveCurrentSeason(ctx, l.Code, season, currentSeasonTTL)
+			return season, nil
+		}
+	}
+
+	// No fixture data anywhere; the newest configured season is the best guess.
+	if len(l.Seasons) > 0 {
+		latest := l.Seasons[0]
+		for _, s := range l.Seasons {
+			if s > latest {
+				latest = s
+			}
+		}
+		return latest, nil
+	}
+
+	return 0, domain.ErrSeasonNotFound
+}
+
+func (sc *SeasonCalendar) HasSeason(ctx context.Context, league string, season int) (bool, error) {
+	l, err := sc.leagues.ByCode(league)
+	if err != nil {
+		return false, err
+	}
+	if len(l.Seasons) == 0 || slices.Contains(l.Seasons, season) {
+		return true, nil
+	}
+
+	current, err := sc.CurrentSeason(ctx, l.Code)
+	if err != nil {
+		return false, err
+	}
+	return season == current, nil
+}
+
+// RoundWindow returns the from/to dates of a round. Windows are read from
+// Redis and discovered from the season's fixtures when missing; a round
+// that is still unknown after discovery is domain.ErrRoundNotFound.
+func (sc *SeasonCalendar) RoundWindow(ctx context.Context, league string, season int, round string) (domain.RoundQuery, error) {
+	q := domain.RoundQuery{League: league, Season: season, Round: normalizeRound(round)}
+
+	l, err := sc.leagues.ByCode(league)
+	if err != nil {
+		return q, err
+	}
+	q.League = l.Code
+
+	if from, to, err := sc.fixtures.GetRoundWindow(ctx, q); err == nil && from != "" && to != "" {
+		q.From, q.To = from, to
+		return q, nil
+	}
+
+	// a season that was discovered already lists all of its rounds
+	if from, to, err := sc.seasons.GetSeasonWindow(ctx, l.Code, season); err == nil && from != "" && to != "" {
+		return q, domain.ErrRoundNotFound
+	}
+
+	if _, err := sc.discover(ctx, l, season); err != nil {
+		return q, err
+	}
+	if from, to, err := sc.fixtures.GetRoundWindow(ctx, q); err == nil && from != "" && to != "" {
+		q.From, q.To = from, to
+		return q, nil
+	}
+	return q, domain.ErrRoundNotFound
+}
+
+// SeasonWindow returns the from/to dates of a whole season: the discovered
+// window when known, otherwise the league's calendar config.
+func (sc *SeasonCalendar) SeasonWindow(ctx context.Context, league string, season int) (domain.RoundQuery, error) {
+	l, err := sc.leagues.ByCode(league)
+	if err != nil {
+		return domain.RoundQuery{League: league, Season: season}, err
+	}
+
+	q := domain.RoundQuery{League: l.Code, Season: season}
+	q.From, q.To, err = sc.seasons.GetSeasonWindow(ctx, l.Code, season)
+	if err != nil || q.From == "" || q.To == "" {
+		q.From, q.To = seasonBounds(l, season)
+	}
+	return q, nil
+}
+
+// discover pulls a season's fixtures and stores the season window plus the
+// window of every round found in them.
+func (sc *SeasonCalendar) discover(ctx context.Context, l *domain.LeagueConfig, season int) (bool, error) {
+	from, to := seasonBounds(l, season)
+	fixtures, err := sc.api.PrevFixtures(ctx, l.APISportsID, season, from, to)
+	if err != nil {
+		return false, err
+	}
+	if fixtures == nil || len(*fixtures) == 0 {
+		return false, nil
+	}
+
+	windows := roundWindows(*fixtures)
+	first, last := "", ""
+	for round, w := range windows {
+		rq := domain.RoundQuery{League: l.Code, Season: season, Round: round, From: w.From, To: w.To}
+		if err := sc.fixtures.SaveRoundWindow(ctx, rq); err != nil {
+			return true, err
+		}
+		if first == "" || w.From < first {
+			first = w.From
+		}
+		if w.To > last {
+			last = w.To
+		}
+	}
+
+	if err := sc.seasons.SaveSeasonWindow(ctx, l.Code, season, first, last); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func (sc *SeasonCalendar) candidateSeasons(l *domain.LeagueConfig) []int {
+	running := seasonAt(l, sc.now())
+
+	candidates := []int{running}
+	configured := append([]int(nil), l.Seasons...)
+	sort.Sort(sort.Reverse(sort.IntSlice(configured)))
+	for _, s := range configured {
+		if s < running {
+			candidates = append(candidates, s)
+		}
+	}
+	return candidates
+}
+
+// seasonAt returns the season (named by its starting year) running at t.
+func seasonAt(l *domain.LeagueConfig, t time.Time) int {
+	start := l.Calendar.StartMonth
+	if start <= 0 || start <= l.Calendar.EndMonth {
+		return t.Year()
+	}
+	if int(t.Month()) >= start {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+// seasonBounds returns the calendar-config window of a season as YYYY-MM-DD.
+func seasonBounds(l *domain.LeagueConfig, season int) (string, string) {
+	startMonth, endMonth := l.Calendar.StartMonth, l.Calendar.EndMonth
+	if startMonth <= 0 || endMonth <= 0 {
+		startMonth, endMonth = 1, 12
+	}
+
+	endYear := season
+	if startMonth > endMonth {
+		endYear = season + 1
+	}
+
+	from := time.Date(season, time.Month(startMonth), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(endYear, time.Month(endMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+	return from.Format("2006-01-02"), to.Format("2006-01-02")
+}
+
+type dateWindow struct {
+	From string
+	To   string
+}
+
+// roundWindows groups fixtures by normalized round and returns the first
+// and last match date of each round.
+func roundWindows(fixtures []domain.PrevFixtures) map[string]dateWindow {
+	windows := map[string]dateWindow{}
+	for _, f := range fixtures {
+		if len(f.Date) < 10 {
+			continue
+		}
+		day := f.Date[:10]
+		round := normalizeRound(f.LeagueRound)
+
+		w, ok := windows[round]
+		if !ok {
+			windows[round] = dateWindow{From: day, To: day}
+			continue
+		}
+		if day < w.From {
+			w.From = day
+		}
+		if day > w.To {
+			w.To = day
+		}
+		windows[round] = w
+	}
+	return windows
+}
@@ -0,0 +1,157 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func newCalendar(t *testing.T, past ...domain.PrevFixtures) (*usecase.SeasonCalendar, *countingAPI, *memorySeasons) {
+	t.Helper()
+	leagues, _ := loadConfig(t)
+	api := &countingAPI{APIService: fake.NewAPIService()}
+	api.Past = past
+	seasons := &memorySeasons{current: map[string]int{}, windows: map[string][2]string{}}
+	return usecase.NewSeasonCalendar(api, memoryRounds{}, seasons, leagues), api, seasons
+}
+
+func round(id int, date, name string) domain.PrevFixtures {
+	return domain.PrevFixtures{FixtureID: id, Date: date + "T13:00:00+00:00", LeagueRound: name}
+}
+
+func TestRoundWindowIsDiscoveredFromFixtures(t *testing.T) {
+	calendar, api, _ := newCalendar(t,
+		round(1, "2023-10-07", "Regular Season - 1"),
+		round(2, "2023-10-08", "Regular Season - 1"),
+		round(3, "2023-10-14", "Regular Season - 2"),
+		round(4, "2023-10-16", "Regular Season - 2"),
+	)
+	ctx := context.Background()
+
+	q, err := calendar.RoundWindow(ctx, "eth", 2023, "Regular Season - 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.League != "ETH" || q.Round != "2" || q.From != "2023-10-14" || q.To != "2023-10-16" {
+		t.Errorf("round 2 = %+v, want ETH round 2 from 2023-10-14 to 2023-10-16", q)
+	}
+
+	if q, err := calendar.RoundWindow(ctx, "ETH", 2023, "1"); err != nil || q.From != "2023-10-07" || q.To != "2023-10-08" {
+		t.Errorf("round 1 = %+v, %v, want it from the same discovery", q, err)
+	}
+	if _, err := calendar.RoundWindow(ctx, "ETH", 2023, "12"); !errors.Is(err, domain.ErrRoundNotFound) {
+		t.Errorf("unknown round: err = %v, want ErrRoundNotFound rather than the whole season", err)
+	}
+	if api.count() != 1 {
+		t.Errorf("season fetched %d times, want once", api.count())
+	}
+}
+
+func TestRoundWindowOfSeasonWithoutFixtures(t *testing.T) {
+	calendar, _, _ := newCalendar(t)
+
+	if _, err := calendar.RoundWindow(context.Background(), "ETH", 2021, "1"); !errors.Is(err, domain.ErrRoundNotFound) {
+		t.Errorf("err = %v, want ErrRoundNotFound", err)
+	}
+}
+
+func TestRoundWindowReportsUpstreamFailure(t *testing.T) {
+	calendar, api, _ := newCalendar(t)
+	api.Err = errors.New("api-sports is down")
+
+	if _, err := calendar.RoundWindow(context.Background(), "ETH", 2023, "1"); err == nil || errors.Is(err, domain.ErrRoundNotFound) {
+		t.Errorf("err = %v, want the upstream error", err)
+	}
+}
+
+func TestHasSeasonAcceptsTheRunningSeason(t *testing.T) {
+	calendar, _, seasons := newCalendar(t)
+	seasons.current["ETH"] = 2026
+
+	for season, want := range map[int]bool{2022: true, 2026: true, 2025: false} {
+		got, err := calendar.HasSeason(context.Background(), "ETH", season)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("HasSeason(%d) = %v, want %v", season, got, want)
+		}
+	}
+}
+
+// countingAPI counts the season fetches of the fake API.
+type countingAPI struct {
+	*fake.APIService
+	calls int
+}
+
+func (a *countingAPI) PrevFixtures(ctx context.Context, leagueID, season int, from, to string) (*[]domain.PrevFixtures, error) {
+	a.calls++
+	return a.APIService.PrevFixtures(ctx, leagueID, season, from, to)
+}
+
+func (a *countingAPI) count() int { return a.calls }
+
+// memoryRounds keeps round windows; it is a map so copies share it.
+type memoryRounds map[string][2]string
+
+func roundKey(q domain.RoundQuery) string {
+	return fmt.Sprintf("%s:%d:%s", q.League, q.Season, q.Round)
+}
+
+func (m memoryRounds) SaveFixturesByRound(ctx context.Context, q domain.RoundQuery, fixtures []domain.PrevFixtures) error {
+	return nil
+}
+
+func (m memoryRounds) SaveRoundWindow(ctx context.Context, q domain.RoundQuery) error {
+	m[roundKey(q)] = [2]string{q.From, q.To}
+	return nil
+}
+
+func (m memoryRounds) GetFixturesByRound(ctx context.Context, q domain.RoundQuery) (*[]domain.PrevFixtures, error) {
+	return nil, errors.New("not cached")
+}
+
+func (m memoryRounds) GetRoundWindow(ctx context.Context, q domain.RoundQuery) (string, string, error) {
+	w, ok := m[roundKey(q)]
+	if !ok {
+		return "", "", errors.New("not cached")
+	}
+	return w[0], w[1], nil
+}
+
+type memorySeasons struct {
+	current map[string]int
+	windows map[string][2]string
+}
+
+func (m *memorySeasons) GetCurrentSeason(ctx context.Context, league string) (int, error) {
+	if season, ok := m.current[league]; ok {
+		return season, nil
+	}
+	return 0, domain.ErrSeasonNotFound
+}
+
+func (m *memorySeasons) SaveCurrentSeason(ctx context.Context, league string, season int, ttl time.Duration) error {
+	m.current[league] = season
+	return nil
+}
+
+func (m *memorySeasons) GetSeasonWindow(ctx context.Context, league string, season int) (string, string, error) {
+	w, ok := m.windows[fmt.Sprintf("%s:%d", league, season)]
+	if !ok {
+		return "", "", domain.ErrSeasonNotFound
+	}
+	return w[0], w[1], nil
+}
+
+func (m *memorySeasons) SaveSeasonWindow(ctx context.Context, league string, season int, from, to string) error {
+	m.windows[fmt.Sprintf("%s:%d", league, season)] = [2]string{from, to}
+	return nil
+}
@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+// defaultTieBreakers is used for leagues whose config names none.
+var defaultTieBreakers = []string{"goal_difference", "goals_for", "head_to_head"}
+
+// formLength is how many recent results a computed form string shows.
+const formLength = 5
+
+// StandingsOptions narrows a computed table.
+type StandingsOptions struct {
+	// AsOf is a round number ("12") or a date ("2023-01-31"); only results
+	// up to and including it count. Empty counts every result.
+	AsOf string
+	// Venue is "home" or "away" to count only those matches of each team.
+	Venue string
+}
+
+// ValidVenue reports whether venue is an accepted StandingsOptions.Venue.
+func ValidVenue(venue string) bool {
+	return venue == "" || venue == "home" || venue == "away"
+}
+
+type tableRow struct {
+	key      string
+	standing domain.Standing
+	results  []byte
+}
+
+// ComputeStandings builds a league table from match results. Only finished
+// matches count; every team that appears in results gets a row, even when
+// the options leave it with no matches.
+func ComputeStandings(results []domain.PrevFixtures, opts StandingsOptions, tieBreakers []string) []domain.Standing {
+	if len(tieBreakers) == 0 {
+		tieBreakers = defaultTieBreakers
+	}
+
+	rows := map[string]*tableRow{}
+	row := func(t domain.MTeam) *tableRow {
+		key := teamKey(t)
+		r, ok := rows[key]
+		if !ok {
+			r = &tableRow{key: key, standing: domain.Standing{TeamID: t.ID, TeamName: t.Name, TeamLogo: t.Logo}}
+			rows[key] = r
+		}
+		return r
+	}
+
+	var counted []domain.PrevFixtures
+	seen := map[int]bool{}
+	for _, f := range results {
+		row(f.HomeTeam)
+		row(f.AwayTeam)
+		if f.FixtureID != 0 {
+			if seen[f.FixtureID] {
+				continue
+			}
+			seen[f.FixtureID] = true
+		}
+		if finishedResult(f) && playedBy(f, opts.AsOf) {
+			counted = append(counted, f)
+		}
+	}
+	slices.SortStableFunc(counted, func(a, b domain.PrevFixtures) int { return strings.Compare(a.Date, b.Date) })
+
+	for _, f := range counted {
+		home, away := *f.Goals.Home, *f.Goals.Away
+		if opts.Venue != "away" {
+			row(f.HomeTeam).record(home, away)
+		}
+		if opts.Venue != "home" {
+			row(f.AwayTeam).record(away, home)
+		}
+	}
+
+	table := make([]*tableRow, 0, len(rows))
+	for _, r := range rows {
+		r.standing.GoalsDiff = r.standing.GoalsFor - r.standing.GoalsAgainst
+		r.standing.Form = string(r.results[max(0, len(r.results)-formLength):])
+		table = append(table, r)
+	}
+
+	slices.SortFunc(table, func(a, b *tableRow) int {
+		if a.standing.Points != b.standing.Points {
+			return b.standing.Points - a.standing.Points
+		}
+		return strings.Compare(a.standing.TeamName, b.standing.TeamName)
+	})
+
+	// teams level on points are ordered by the league's tie-breakers
+	for start := 0; start < len(table); {
+		end := start + 1
+		for end < len(table) && table[end].standing.Points == table[start].standing.Points {
+			end++
+		}
+		orderTied(table[start:end], counted, opts.Venue, tieBreakers)
+		start = end
+	}
+
+	standings := make([]domain.Standing, len(table))
+	for i, r := range table {
+		standings[i] = r.standing
+		standings[i].Rank = i + 1
+	}
+	return standings
+}
+
+// orderTied orders teams level on points by the tie-breakers. The
+// head-to-head criterion only looks at matches between the level teams;
+// when it separates some of them but leaves a smaller group level, it is
+// applied again to the matches between just those teams, before the
+// criteria after it.
+func orderTied(group []*tableRow, results []domain.PrevFixtures, venue string, tieBreakers []string) {
+	if len(group) < 2 {
+		return
+	}
+	h2h := miniTable(group, results, venue)
+	slices.SortStableFunc(group, func(a, b *tableRow) int {
+		return compareTied(a, b, h2h, tieBreakers)
+	})
+
+	i := slices.Index(tieBreakers, "head_to_head")
+	if i < 0 {
+		return
+	}
+	upToH2H := tieBreakers[:i+1]
+	for start := 0; start < len(group); {
+		end := start + 1
+		for end < len(group) && compareTied(group[start], group[end], h2h, upToH2H) == 0 {
+			end++
+		}
+		// a group head-to-head could not split at all stays as sorted
+		if end-start < len(group) {
+			orderTied(group[start:end], results, venue, tieBreakers)
+		}
+		start = end
+	}
+}
+
+func (r *tableRow) record(scored, conceded int) {
+	s := &r.standing
+	s.MatchesPlayed++
+	s.GoalsFor += scored
+	s.GoalsAgainst += conceded
+	switch {
+	case scored > conceded:
+		s.Wins++
+		s.Points += 3
+		r.results = append(r.results, 'W')
+	case scored == conceded:
+		s.Draws++
+		s.Points++
+		r.results = append(r.results, 'D')
+	default:
+		s.Losses++
+		r.results = append(r.results, 'L')
+	}
+}
+
+// miniTable is the table of the matches played between the teams of group.
+func miniTable(group []*tableRow, results []domain.PrevFixtures, venue string) map[string]*tableRow {
+	h2h := map[string]*tableRow{}
+	for _, r := range group {
+		h2h[r.key] = &tableRow{key: r.key}
+	}
+	for _, f := range results {
+		home, okHome := h2h[teamKey(f.HomeTeam)]
+		away, okAway := h2h[teamKey(f.AwayTeam)]
+		if !okHome || !okAway {
+			continue
+		}
+		if venue != "away" {
+			home.record(*f.Goals.Home, *f.Goals.Away)
+		}
+		if venue != "home" {
+			away.record(*f.Goals.Away, *f.Goals.Home)
+		}
+	}
+	return h2h
+}
+
+func compareTied(a, b *tableRow, h2h map[string]*tableRow, tieBreakers []string) int {
+	for _, tb := range tieBreakers {
+		var diff int
+		switch tb {
+		case "head_to_head":
+			ha, hb := h2h[a.key].standing, h2h[b.key].standing
+			diff = cmpDesc(ha.Points, hb.Points)
+			if diff == 0 {
+				diff = cmpDesc(ha.GoalsFor-ha.GoalsAgainst, hb.GoalsFor-hb.GoalsAgainst)
+			}
+			if diff == 0 {
+				diff = cmpDesc(ha.GoalsFor, hb.GoalsFor)
+			}
+		case "goal_difference":
+			diff = cmpDesc(a.standing.GoalsDiff, b.standing.GoalsDiff)
+		case "goals_for":
+			diff = cmpDesc(a.standing.GoalsFor, b.standing.GoalsFor)
+		}
+		if diff != 0 {
+			return diff
+		}
+	}
+	return 0
+}
+
+func cmpDesc(a, b int) int {
+	return b - a
+}
+
+// teamKey identifies a team by api-sports id, or by name when the provider
+// gave none.
+func teamKey(t domain.MTeam) string {
+	if t.ID != 0 {
+		return strconv.Itoa(t.ID)
+	}
+	return strings.ToLower(t.Name)
+}
+
+func finishedResult(f domain.PrevFixtures) bool {
+	return finishedStatuses[f.Status.Short] && f.Goals.Home != nil && f.Goals.Away != nil
+}
+
+// playedBy reports whether f falls on or before asOf, a round number or a
+// YYYY-MM-DD date.
+func playedBy(f domain.PrevFixtures, asOf string) bool {
+	if asOf == "" {
+		return true
+	}
+	if round, err := strconv.Atoi(asOf); err == nil {
+		played, err := strconv.Atoi(normalizeRound(f.LeagueRound))
+		return err == nil && played <= round
+	}
+	return len(f.Date) >= 10 && f.Date[:10] <= asOf
+}
+
+// ValidAsOf reports whether asOf is empty, a round number or a YYYY-MM-DD date.
+func ValidAsOf(asOf string) bool {
+	if asOf == "" {
+		return true
+	}
+	if round, err := strconv.Atoi(asOf); err == nil {
+		return round > 0
+	}
+	return isoDate.MatchString(asOf) && len(asOf) == 10
+}
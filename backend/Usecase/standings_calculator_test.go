@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func finished(id int, date string, home, away domain.MTeam, homeGoals, awayGoals int) domain.PrevFixtures {
+	return domain.PrevFixtures{
+		FixtureID: id,
+		Date:      date,
+		HomeTeam:  home,
+		AwayTeam:  away,
+		Goals:     domain.Goals{Home: &homeGoals, Away: &awayGoals},
+		Status:    domain.Status{Short: "FT"},
+	}
+}
+
+func TestHeadToHeadIsReappliedToTeamsStillLevel(t *testing.T) {
+	a := domain.MTeam{ID: 1, Name: "A"}
+	b := domain.MTeam{ID: 2, Name: "B"}
+	c := domain.MTeam{ID: 3, Name: "C"}
+	d := domain.MTeam{ID: 4, Name: "D"}
+
+	// A, B and C finish on 6 points and level on the points and goal
+	// difference of their three meetings; A scored fewest in them. B beat
+	// C, while C has the better goal difference overall.
+	results := []domain.PrevFixtures{
+		finished(1, "2023-01-01", b, c, 2, 1),
+		finished(2, "2023-01-08", a, b, 1, 0),
+		finished(3, "2023-01-15", c, a, 1, 0),
+		finished(4, "2023-01-22", a, d, 1, 0),
+		finished(5, "2023-01-29", b, d, 1, 0),
+		finished(6, "2023-02-05", c, d, 5, 0),
+	}
+
+	table := usecase.ComputeStandings(results, usecase.StandingsOptions{}, []string{"head_to_head", "goal_difference", "goals_for"})
+
+	var order []string
+	for _, row := range table {
+		order = append(order, row.TeamName)
+	}
+	if got := fmt.Sprint(order); got != "[B C A D]" {
+		t.Errorf("table order = %s, want [B C A D]: B and C are split by their own meeting", got)
+	}
+}
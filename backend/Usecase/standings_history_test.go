@@ -0,0 +1,116 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	"github.com/abrshodin/ethio-fb-backend/Infrastructure/fake"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+func table(date string, rows ...domain.Standing) domain.StandingsSnapshot {
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+	return domain.StandingsSnapshot{Date: date, Standings: rows}
+}
+
+func TestHistoryFollowsTheDailySnapshots(t *testing.T) {
+	leagues, teams := loadConfig(t)
+	repo := fake.NewStandingsRepo(fake.NewAPIService())
+	ctx := context.Background()
+
+	giorgis := domain.Standing{TeamID: 10, TeamName: "Kedus Giorgis"}
+	fasil := domain.Standing{TeamID: 20, TeamName: "Fasil Ketema"}
+	buna := domain.Standing{TeamID: 30, TeamName: "Ethiopia Bunna"}
+	for _, s := range []domain.StandingsSnapshot{
+		table("2023-10-14", giorgis, fasil, buna), // replaced by the later save of the day
+		table("2023-10-08", fasil, buna, giorgis),
+		table("2023-10-14", fasil, giorgis, buna),
+		table("2023-10-21", giorgis, fasil, buna),
+	} {
+		if err := repo.SaveSnapshot(ctx, 363, 2023, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	standings := usecase.NewStandingsUsecase(repo, nil, leagues, teams)
+
+	for _, team := range []string{"10", "Kedus Giorgis", "ቅዱስ ጊዮርጊስ"} {
+		history, err := standings.History(ctx, 363, 2023, team)
+		if err != nil {
+			t.Fatalf("%s: %v", team, err)
+		}
+		want := []domain.StandingsPoint{
+			{Date: "2023-10-08", Rank: 3},
+			{Date: "2023-10-14", Rank: 2, Change: 1},
+			{Date: "2023-10-21", Rank: 1, Change: 1},
+		}
+		if history.Source != "snapshots" || history.TeamID != 10 || len(history.Points) != len(want) {
+			t.Fatalf("%s: history = %+v, want Kedus Giorgis over three snapshots", team, history)
+		}
+		for i, p := range history.Points {
+			if p != want[i] {
+				t.Errorf("%s: point %d = %+v, want %+v", team, i, p, want[i])
+			}
+		}
+	}
+
+	if _, err := standings.History(ctx, 363, 2023, "Arsenal"); err != domain.ErrTeamNotFound {
+		t.Errorf("team outside the table: err = %v, want ErrTeamNotFound", err)
+	}
+	if _, err := standings.History(ctx, 1, 2023, "10"); err != domain.ErrLeagueNotFound {
+		t.Errorf("unknown league: err = %v, want ErrLeagueNotFound", err)
+	}
+}
+
+func TestHistoryIsComputedWithoutSnapshots(t *testing.T) {
+	leagues, teams := loadConfig(t)
+	results := seasonOfResults{results: []domain.PrevFixtures{
+		result(1, "2023-10-07", "Regular Season - 1", "Kedus Giorgis", "Fasil Ketema", 0, 1),
+		result(2, "2023-10-14", "Regular Season - 2", "Fasil Ketema", "Kedus Giorgis", 0, 2),
+		result(3, "2023-10-21", "Regular Season - 3", "Kedus Giorgis", "Fasil Ketema", 1, 0),
+	}}
+	standings := usecase.NewStandingsUsecase(fake.NewStandingsRepo(fake.NewAPIService()), results, leagues, teams)
+
+	history, err := standings.History(context.Background(), 363, 2023, "kedus giorgis")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.StandingsPoint{
+		{Date: "2023-10-07", Rank: 2, Points: 0, MatchesPlayed: 1},
+		// level on points, ahead on goal difference
+		{Date: "2023-10-14", Rank: 1, Points: 3, MatchesPlayed: 2, Change: 1},
+		{Date: "2023-10-21", Rank: 1, Points: 6, MatchesPlayed: 3},
+	}
+	if history.Source != "computed" || len(history.Points) != len(want) {
+		t.Fatalf("history = %+v, want three computed matchdays", history)
+	}
+	for i, p := range history.Points {
+		if p != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+}
+
+func result(id int, date, round, home, away string, homeGoals, awayGoals int) domain.PrevFixtures {
+	return domain.PrevFixtures{
+		FixtureID:   id,
+		Date:        date + "T13:00:00+00:00",
+		LeagueRound: round,
+		HomeTeam:    domain.MTeam{Name: home},
+		AwayTeam:    domain.MTeam{Name: away},
+		Goals:       domain.Goals{Home: &homeGoals, Away: &awayGoals},
+		Status:      domain.Status{Short: "FT"},
+	}
+}
+
+// seasonOfResults serves the same results for any season.
+type seasonOfResults struct {
+	usecase.IFixturesUsecase
+	results []domain.PrevFixtures
+}
+
+func (r seasonOfResults) SeasonResults(ctx context.Context, league string, season int) ([]domain.PrevFixtures, error) {
+	return r.results, nil
+}
@@ -3,30 +3,200 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	domain "github.com/abrshodin/ethio-fb-backend/Domain"
 )
 
 type IStandingsUsecase interface {
 	GetStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error)
+	ComputeStandings(ctx context.Context, leagueID, season int, opts StandingsOptions) (*domain.StandingsResponse, error)
+	History(ctx context.Context, leagueID, season int, team string) (*domain.StandingsHistory, error)
 }
 
 type StandingsUsecase struct {
 	standingsRepo domain.IStandingsRepo
+	fixtures      IFixturesUsecase
+	leagues       domain.ILeagueRegistry
+	teams         ITeamResolver
 }
 
-func NewStandingsUsecase(standingsRepo domain.IStandingsRepo) IStandingsUsecase {
+func NewStandingsUsecase(standingsRepo domain.IStandingsRepo, fixtures IFixturesUsecase, leagues domain.ILeagueRegistry, teams ITeamResolver) IStandingsUsecase {
 	return &StandingsUsecase{
 		standingsRepo: standingsRepo,
+		fixtures:      fixtures,
+		leagues:       leagues,
+		teams:         teams,
 	}
 }
 
+// GetStandings returns the provider's table, or one computed from the
+// season's results when the provider has none.
 func (u *StandingsUsecase) GetStandings(ctx context.Context, leagueID, season int) (*domain.StandingsResponse, error) {
 
 	standings, err := u.standingsRepo.GetStandings(ctx, leagueID, season)
+	if err == nil && standings != nil && len(standings.Standings) > 0 {
+		return standings, nil
+	}
+
+	computed, cerr := u.ComputeStandings(ctx, leagueID, season, StandingsOptions{})
+	if cerr == nil && len(computed.Standings) > 0 {
+		fmt.Printf("standings for league %d season %d computed from results (upstream: %v)\n", leagueID, season, err)
+		return computed, nil
+	}
+
 	if err != nil {
 		return nil, fmt.Errorf("failed to get standings: %w", err)
 	}
-
 	return standings, nil
 }
+
+// ComputeStandings builds the table of a season from its stored results.
+func (u *StandingsUsecase) ComputeStandings(ctx context.Context, leagueID, season int, opts StandingsOptions) (*domain.StandingsResponse, error) {
+	league, err := u.leagues.ByAPISportsID(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := u.fixtures.SeasonResults(ctx, league.Code, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results: %w", err)
+	}
+
+	return &domain.StandingsResponse{
+		LeagueID:    league.APISportsID,
+		LeagueName:  league.Name("en"),
+		Country:     league.Country,
+		Season:      season,
+		Standings:   ComputeStandings(results, opts, league.TieBreakers),
+		LastUpdated: time.Now().UTC().Format(time.RFC3339),
+		Provider:    "computed",
+	}, nil
+}
+
+// History returns a team's rank and points over a season. team is an id or
+// a name in any spelling. The daily snapshots are used when there are any;
+// otherwise the table is computed from results after every matchday.
+func (u *StandingsUsecase) History(ctx context.Context, leagueID, season int, team string) (*domain.StandingsHistory, error) {
+	league, err := u.leagues.ByAPISportsID(leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &domain.StandingsHistory{LeagueID: leagueID, Season: season, Source: "snapshots", Points: []domain.StandingsPoint{}}
+	snapshots, err := u.standingsRepo.GetSnapshots(ctx, leagueID, season)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		history.Source = "computed"
+		if snapshots, err = u.matchdayTables(ctx, league, season); err != nil {
+			return nil, err
+		}
+	}
+
+	isTeam := u.teamMatcher(team, league.Code)
+	for _, snapshot := range snapshots {
+		i := slices.IndexFunc(snapshot.Standings, isTeam)
+		if i < 0 {
+			continue
+		}
+		row := snapshot.Standings[i]
+		history.TeamID, history.TeamName = row.TeamID, row.TeamName
+
+		point := domain.StandingsPoint{Date: snapshot.Date, Rank: row.Rank, Points: row.Points, MatchesPlayed: row.MatchesPlayed}
+		if n := len(history.Points); n > 0 {
+			point.Change = history.Points[n-1].Rank - row.Rank
+		}
+		history.Points = append(history.Points, point)
+	}
+
+	if history.TeamName == "" && len(snapshots) > 0 {
+		return nil, domain.ErrTeamNotFound
+	}
+	return history, nil
+}
+
+// matchdayTables computes the table after each round that has results,
+// dated by the round's last match.
+func (u *StandingsUsecase) matchdayTables(ctx context.Context, league *domain.LeagueConfig, season int) ([]domain.StandingsSnapshot, error) {
+	results, err := u.fixtures.SeasonResults(ctx, league.Code, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results: %w", err)
+	}
+
+	played := map[string]bool{}
+	for _, f := range results {
+		if finishedResult(f) {
+			played[normalizeRound(f.LeagueRound)] = true
+		}
+	}
+
+	var rounds []string
+	for round := range played {
+		if _, err := strconv.Atoi(round); err == nil {
+			rounds = append(rounds, round)
+		}
+	}
+	slices.SortFunc(rounds, func(a, b string) int {
+		x, _ := strconv.Atoi(a)
+		y, _ := strconv.Atoi(b)
+		return x - y
+	})
+
+	windows := roundWindows(results)
+	snapshots := make([]domain.StandingsSnapshot, 0, len(rounds))
+	for _, round := range rounds {
+		snapshots = append(snapshots, domain.StandingsSnapshot{
+			Date:      windows[round].To,
+			Standings: ComputeStandings(results, StandingsOptions{AsOf: round}, league.TieBreakers),
+		})
+	}
+	return snapshots, nil
+}
+
+// teamMatcher matches table rows to team: by id when team is numeric,
+// otherwise by name, resolving both sides through the alias list so
+// "St. George" finds "Kidus Giorgis". Rows without an id, as TheSportsDB
+// gives them, are matched to an id through the name registered for it.
+func (u *StandingsUsecase) teamMatcher(team, league string) func(domain.Standing) bool {
+	if id, err := strconv.Atoi(team); err == nil {
+		var byName func(domain.Standing) bool
+		for _, t := range u.teams.Teams(league) {
+			if t.ID == id {
+				byName = u.nameMatcher(t.Name, league)
+			}
+		}
+		return func(s domain.Standing) bool {
+			if s.TeamID != 0 || byName == nil {
+				return s.TeamID == id
+			}
+			return byName(s)
+		}
+	}
+	return u.nameMatcher(team, league)
+}
+
+func (u *StandingsUsecase) nameMatcher(team, league string) func(domain.Standing) bool {
+	resolved := map[string]string{}
+	canonical := func(name string) string {
+		if c, ok := resolved[name]; ok {
+			return c
+		}
+		if matches := u.teams.Resolve(name, league, 1); len(matches) > 0 {
+			resolved[name] = matches[0].Name
+		}
+		return resolved[name]
+	}
+
+	want := canonical(team)
+	return func(s domain.Standing) bool {
+		if strings.EqualFold(s.TeamName, team) {
+			return true
+		}
+		return want != "" && canonical(s.TeamName) == want
+	}
+}
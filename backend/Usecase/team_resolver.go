@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const (
+	// minTeamScore is the lowest similarity reported as a match.
+	minTeamScore = 0.6
+	// registerTeamScore is the similarity needed to attach an upstream team
+	// to a seeded entry instead of adding a new one.
+	registerTeamScore = 0.8
+)
+
+type ITeamResolver interface {
+	domain.ITeamDirectory
+	// Load merges the teams registered in earlier runs into the seed list.
+	Load(ctx context.Context) error
+	// Resolve returns up to limit teams ranked by how well they match query.
+	Resolve(query, league string, limit int) []domain.TeamMatch
+	// ResolveID returns the api-sports id of the best match for query.
+	ResolveID(ctx context.Context, query, league string) (int, error)
+	// Register records an upstream team id and name, linking it to the
+	// seeded entry it matches.
+	Register(ctx context.Context, id int, name, league string) error
+}
+
+// TeamResolver maps the many names of a team (English, Ge'ez script,
+// transliterated Amharic, misspellings) onto one canonical entry.
+type TeamResolver struct {
+	repo domain.ITeamAliasRepo
+
+	mu    sync.RWMutex
+	teams []domain.TeamAlias
+	forms [][]string // folded canonical name and aliases, per team
+}
+
+func NewTeamResolver(repo domain.ITeamAliasRepo, seed []domain.TeamAlias) *TeamResolver {
+	r := &TeamResolver{repo: repo}
+	for _, t := range seed {
+		r.add(t)
+	}
+	return r
+}
+
+func (r *TeamResolver) Load(ctx context.Context) error {
+	stored, err := r.repo.AllTeamAliases(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, t := range stored {
+		if i := r.indexOf(t.League, t.Name); i >= 0 {
+			r.merge(i, t.ID, t.Aliases...)
+			continue
+		}
+		r.add(t)
+	}
+	return nil
+}
+
+func (r *TeamResolver) Teams(league string) []domain.TeamAlias {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var teams []domain.TeamAlias
+	for _, t := range r.teams {
+		if league == "" || t.League == league {
+			t.Aliases = slices.Clone(t.Aliases)
+			teams = append(teams, t)
+		}
+	}
+	return teams
+}
+
+func (r *TeamResolver) Resolve(query, league string, limit int) []domain.TeamMatch {
+	q := foldTeamName(query)
+	if q == "" {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []domain.TeamMatch
+	for i, t := range r.teams {
+		if league != "" && t.League != league {
+			continue
+		}
+
+		best, matched := 0.0, ""
+		for j, form := range r.forms[i] {
+			if s := teamSimilarity(q, form); s > best {
+				best = s
+				matched = t.Name
+				if j > 0 {
+					matched = t.Aliases[j-1]
+				}
+			}
+		}
+		if best >= minTeamScore {
+			matches = append(matches, domain.TeamMatch{ID: t.ID, Name: t.Name, League: t.League, Matched: matched, Score: best})
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b domain.TeamMatch) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func (r *TeamResolver) ResolveID(ctx context.Context, query, league string) (int, error) {
+	matches := r.Resolve(query, league, 1)
+	if len(matches) == 0 || matches[0].ID == 0 {
+		return 0, domain.ErrTeamNotFound
+	}
+	return matches[0].ID, nil
+}
+
+func (r *TeamResolver) Register(ctx context.Context, id int, name, league string) error {
+	r.mu.Lock()
+
+	i := -1
+	for k, t := range r.teams {
+		if t.ID == id && t.League == league {
+			i = k
+			break
+		}
+	}
+	if i < 0 {
+		q := foldTeamName(name)
+		best := registerTeamScore
+		for k, t := range r.teams {
+			if t.League != league || t.ID != 0 {
+				continue
+			}
+			for _, form := range r.forms[k] {
+				if s := teamSimilarity(q, form); s >= best {
+					best, i = s, k
+				}
+			}
+		}
+	}
+
+	if i >= 0 {
+		r.merge(i, id, name)
+	} else {
+		r.add(domain.TeamAlias{ID: id, Name: name, League: league})
+		i = len(r.teams) - 1
+	}
+	team := r.teams[i]
+	team.Aliases = slices.Clone(team.Aliases)
+	r.mu.Unlock()
+
+	return r.repo.SaveTeamAlias(ctx, team)
+}
+
+func (r *TeamResolver) indexOf(league, name string) int {
+	for i, t := range r.teams {
+		if t.League == league && t.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *TeamResolver) add(t domain.TeamAlias) {
+	forms := []string{foldTeamName(t.Name)}
+	for _, a := range t.Aliases {
+		forms = append(forms, foldTeamName(a))
+	}
+	r.teams = append(r.teams, t)
+	r.forms = append(r.forms, forms)
+}
+
+// merge sets the id of team i and adds any aliases it does not have yet.
+func (r *TeamResolver) merge(i, id int, aliases ...string) {
+	if id != 0 {
+		r.teams[i].ID = id
+	}
+	for _, a := range aliases {
+		folded := foldTeamName(a)
+		if a == "" || slices.Contains(r.forms[i], folded) {
+			continue
+		}
+		r.teams[i].Aliases = append(r.teams[i].Aliases, a)
+		r.forms[i] = append(r.forms[i], folded)
+	}
+}
+
+// teamSimilarity scores two folded names between 0 and 1.
+func teamSimilarity(a, b string) float64 {
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	best := editSimilarity(a, b)
+
+	// consonant skeletons absorb the vowel guesswork of transliteration
+	if sa, sb := teamSkeleton(a), teamSkeleton(b); len(sa) >= 2 && len(sb) >= 2 {
+		best = max(best, 0.9*editSimilarity(sa, sb))
+	}
+
+	// "kedus giorgis fc" still names "kedus giorgis"
+	short, long := a, b
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	if len(short) >= 4 && strings.Contains(" "+long+" ", " "+short+" ") {
+		best = max(best, 0.85)
+	}
+	return best
+}
+
+func editSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// teamNameNoise are words that do not tell teams apart.
+var teamNameNoise = map[string]bool{"fc": true, "sc": true, "afc": true, "cf": true, "club": true, "the": true}
+
+// transliteration spellings folded to one form
+var latinFolds = strings.NewReplacer("ph", "f", "kh", "k", "q", "k", "ts", "s", "sh", "s", "zh", "z", "ck", "k")
+
+// foldTeamName reduces a team name to a comparable Latin form: Ge'ez is
+// transliterated, case, punctuation and filler words are dropped, common
+// spelling variants are unified and doubled letters collapsed.
+func foldTeamName(name string) string {
+	if containsGeez(name) {
+		name = transliterateGeez(name)
+	}
+
+	var words []string
+	for _, w := range strings.Fields(normalizeQuery(name)) {
+		if teamNameNoise[w] {
+			continue
+		}
+		words = append(words, w)
+	}
+	folded := latinFolds.Replace(strings.Join(words, " "))
+
+	var b strings.Builder
+	var last rune
+	for _, r := range folded {
+		if r == last && unicode.IsLetter(r) {
+			continue
+		}
+		b.WriteRune(r)
+		last = r
+	}
+	return b.String()
+}
+
+func teamSkeleton(folded string) string {
+	var b strings.Builder
+	for _, r := range folded {
+		if strings.ContainsRune("aeiou ", r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// geezConsonants are the Latin consonants of the Ethiopic syllable rows,
+// U+1200 to U+135F, eight vowel orders per row.
+var geezConsonants = []string{
+	"h", "l", "h", "m", "s", "r", "s", "sh", "q", "qw", "q", "qw", "b", "v", "t", "ch",
+	"h", "hw", "n", "ny", "", "k", "kw", "kh", "khw", "w", "", "z", "zh", "y", "d", "dd",
+	"j", "g", "gw", "gg", "t", "ch", "p", "ts", "ts", "f", "p", "",
+}
+
+// geezVowels are the vowels of the eight orders; the sixth order is usually
+// unvoiced in names.
+var geezVowels = []string{"e", "u", "i", "a", "e", "", "o", "wa"}
+
+// transliterateGeez spells Ethiopic syllables in Latin letters the way
+// club names are usually written ("ቅዱስ ጊዮርጊስ" -> "qdus giyorgis").
+func transliterateGeez(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x1200 || r > 0x135F {
+			if unicode.Is(unicode.Ethiopic, r) {
+				b.WriteRune(' ') // Ethiopic punctuation and numerals
+			} else {
+				b.WriteRune(r)
+			}
+			continue
+		}
+
+		row, order := int(r-0x1200)/8, int(r-0x1200)%8
+		consonant := geezConsonants[row]
+		vowel := geezVowels[order]
+		// first-order syllables of the h and glottal rows read as "a" (ሀዋሳ, አዳማ)
+		if order == 0 && (consonant == "h" || consonant == "") {
+			vowel = "a"
+		}
+		b.WriteString(consonant + vowel)
+	}
+	return b.String()
+}
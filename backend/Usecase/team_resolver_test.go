@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+	usecase "github.com/abrshodin/ethio-fb-backend/Usecase"
+)
+
+// seedTeams carries no Ge'ez aliases, so script queries only match through
+// transliteration.
+var seedTeams = []domain.TeamAlias{
+	{Name: "Saint George", League: "ETH", Aliases: []string{"kedus giorgis"}},
+	{Name: "Fasil Kenema", League: "ETH", Aliases: []string{"fasil ketema"}},
+	{Name: "Hadiya Hossana", League: "ETH"},
+	{Name: "Adama Kenema", League: "ETH", Aliases: []string{"adama"}},
+	{Name: "Arsenal", League: "EPL", Aliases: []string{"gunners"}},
+}
+
+func TestResolveFoldsSpellingsAndScript(t *testing.T) {
+	resolver := usecase.NewTeamResolver(nil, seedTeams)
+
+	for query, want := range map[string]string{
+		"Saint George":    "Saint George",
+		"saint george fc": "Saint George",
+		"St. Georgee":     "Saint George",
+		"Kidus Giyorgis":  "Saint George",
+		"ቅዱስ ጊዮርጊስ":       "Saint George",
+		"Phasil Kenema":   "Fasil Kenema",
+		"ፋሲል ከነማ":         "Fasil Kenema",
+		"hadiya hosaena":  "Hadiya Hossana",
+		"ሀዲያ ሆሳዕና":        "Hadiya Hossana",
+		"አዳማ":             "Adama Kenema",
+		"the gunners":     "Arsenal",
+	} {
+		matches := resolver.Resolve(query, "", 1)
+		if len(matches) == 0 {
+			t.Errorf("Resolve(%q) found nothing, want %s", query, want)
+			continue
+		}
+		if matches[0].Name != want {
+			t.Errorf("Resolve(%q) = %s (%.2f), want %s", query, matches[0].Name, matches[0].Score, want)
+		}
+	}
+
+	for _, query := range []string{"", "fc", "Real Madrid"} {
+		if matches := resolver.Resolve(query, "", 1); len(matches) != 0 {
+			t.Errorf("Resolve(%q) = %+v, want no match", query, matches)
+		}
+	}
+}
+
+func TestResolveRanksAndFiltersByLeague(t *testing.T) {
+	resolver := usecase.NewTeamResolver(nil, seedTeams)
+
+	if matches := resolver.Resolve("arsenal", "ETH", 5); len(matches) != 0 {
+		t.Errorf("Resolve in ETH = %+v, want the EPL team left out", matches)
+	}
+	matches := resolver.Resolve("Saint George", "", 5)
+	for i := 1; i < len(matches); i++ {
+		if matches[i].Score > matches[i-1].Score {
+			t.Errorf("matches not ranked: %+v", matches)
+		}
+	}
+	if len(matches) == 0 || matches[0].Score != 1 || matches[0].Matched != "Saint George" {
+		t.Errorf("exact match = %+v, want score 1 on the canonical name", matches)
+	}
+}
+
+func TestRegisterLinksUpstreamTeams(t *testing.T) {
+	repo := &memoryAliases{}
+	resolver := usecase.NewTeamResolver(repo, seedTeams)
+	ctx := context.Background()
+
+	if _, err := resolver.ResolveID(ctx, "ቅዱስ ጊዮርጊስ", "ETH"); err != domain.ErrTeamNotFound {
+		t.Errorf("ResolveID before registering: err = %v, want ErrTeamNotFound", err)
+	}
+
+	// api-sports spells the seeded team its own way
+	if err := resolver.Register(ctx, 2440, "Saint Georges SC", "ETH"); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := resolver.ResolveID(ctx, "ቅዱስ ጊዮርጊስ", "ETH"); err != nil || id != 2440 {
+		t.Errorf("ResolveID = %d, %v, want 2440 through the seeded entry", id, err)
+	}
+	if len(resolver.Teams("ETH")) != 4 {
+		t.Errorf("%d ETH teams, want the upstream team merged rather than added", len(resolver.Teams("ETH")))
+	}
+	if len(repo.saved) != 1 || repo.saved[0].Name != "Saint George" || repo.saved[0].ID != 2440 {
+		t.Errorf("saved %+v, want Saint George with its upstream id", repo.saved)
+	}
+
+	// a restart picks the link up from the repository
+	restarted := usecase.NewTeamResolver(repo, seedTeams)
+	if err := restarted.Load(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if id, err := restarted.ResolveID(ctx, "kedus giorgis", "ETH"); err != nil || id != 2440 {
+		t.Errorf("ResolveID after Load = %d, %v, want 2440", id, err)
+	}
+}
+
+type memoryAliases struct{ saved []domain.TeamAlias }
+
+func (m *memoryAliases) SaveTeamAlias(ctx context.Context, team domain.TeamAlias) error {
+	m.saved = append(m.saved, team)
+	return nil
+}
+
+func (m *memoryAliases) AllTeamAliases(ctx context.Context) ([]domain.TeamAlias, error) {
+	return m.saved, nil
+}
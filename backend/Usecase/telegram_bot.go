@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	domain "github.com/abrshodin/ethio-fb-backend/Domain"
+)
+
+const telegramHelp = `Ask me anything about the football we cover, e.g. "When do St. George play next?"
+
+Commands:
+/table [league] – the league table (%s)
+/live [league] – matches in play
+/follow <team> – goal and result alerts for a team
+/unfollow <team> – stop the alerts`
+
+// TelegramBot answers Telegram messages: commands directly, anything else
+// through the same intent pipeline as POST /intent/parse. Each chat is one
+// conversation session, so follow-ups keep their context.
+type TelegramBot struct {
+	sender        domain.IChatSender
+	conversations IConversationUsecase
+	intents       *IntentRegistry
+	answers       AnswerUsecase
+	standings     IStandingsUsecase
+	fixtures      IFixturesUsecase
+	follows       IFollowUsecase
+	teams         ITeamResolver
+	leagues       domain.ILeagueRegistry
+	seasons       ISeasonCalendar
+}
+
+func NewTelegramBot(
+	sender domain.IChatSender,
+	conversations IConversationUsecase,
+	intents *IntentRegistry,
+	answers AnswerUsecase,
+	standings IStandingsUsecase,
+	fixtures IFixturesUsecase,
+	follows IFollowUsecase,
+	teams ITeamResolver,
+	leagues domain.ILeagueRegistry,
+	seasons ISeasonCalendar,
+) *TelegramBot {
+	return &TelegramBot{
+		sender:        sender,
+		conversations: conversations,
+		intents:       intents,
+		answers:       answers,
+		standings:     standings,
+		fixtures:      fixtures,
+		follows:       follows,
+		teams:         teams,
+		leagues:       leagues,
+		seasons:       seasons,
+	}
+}
+
+// HandleUpdate replies to the text message of update. Updates without one
+// (edits, joins, stickers) are ignored.
+func (b *TelegramBot) HandleUpdate(ctx context.Context, update domain.TelegramUpdate) error {
+	msg := update.Message
+	if msg == nil || strings.TrimSpace(msg.Text) == "" {
+		return nil
+	}
+	return b.sender.SendMessage(ctx, msg.Chat.ID, b.Reply(ctx, msg.Chat.ID, msg.Text))
+}
+
+// Reply is the bot's answer to text sent in chatID.
+func (b *TelegramBot) Reply(ctx context.Context, chatID int64, text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return b.ask(ctx, chatID, text)
+	}
+
+	command, arg, _ := strings.Cut(text, " ")
+	// in groups commands are addressed as /table@SomeBot
+	command, _, _ = strings.Cut(strings.ToLower(command), "@")
+	arg = strings.TrimSpace(arg)
+
+	switch command {
+	case "/start", "/help":
+		return b.help()
+	case "/table":
+		return b.table(ctx, arg)
+	case "/live":
+		return b.live(ctx, arg)
+	case "/follow":
+		return b.follow(ctx, chatID, arg, true)
+	case "/unfollow":
+		return b.follow(ctx, chatID, arg, false)
+	}
+	return "Unknown command " + command + ".\n\n" + b.help()
+}
+
+// ask runs text through intent parsing, the topic handlers and the answer
+// composer, and returns the Markdown answer.
+func (b *TelegramBot) ask(ctx context.Context, chatID int64, text string) string {
+	conv, intent, err := b.conversations.ParseIn(ctx, domain.TelegramUserID(chatID), text)
+	if err != nil {
+		if errors.Is(err, ErrIntentNotFound) || errors.Is(err, ErrInvalidInput) {
+			return "Sorry, I didn't get that. Try asking about a fixture, a result or the table, or send /help."
+		}
+		fmt.Println("telegram: could not parse intent:", err)
+		return "Sorry, something went wrong. Please try again later."
+	}
+
+	answerContext, err := b.intents.Dispatch(ctx, intent)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrUnsupportedTopic), errors.Is(err, domain.ErrLeagueNotFound), errors.Is(err, ErrInvalidInput):
+			return "Sorry, I can't answer that yet. Send /help to see what I can do."
+		}
+		fmt.Println("telegram: could not fetch data for", intent.Topic+":", err)
+		return "Sorry, I couldn't fetch the data for that. Please try again later."
+	}
+
+	answer, err := b.answers.Compose(ctx, *answerContext)
+	if err != nil {
+		fmt.Println("telegram: could not compose answer:", err)
+		return "Sorry, something went wrong. Please try again later."
+	}
+
+	if err := b.conversations.Remember(ctx, conv, text, intent, answer); err != nil {
+		fmt.Println("could not save conversation:", err)
+	}
+	return answer.Markdown
+}
+
+func (b *TelegramBot) help() string {
+	return fmt.Sprintf(telegramHelp, strings.Join(b.leagueCodes(), ", "))
+}
+
+// league resolves the league named in a command, or the default league
+// when none is named.
+func (b *TelegramBot) league(code string) (*domain.LeagueConfig, error) {
+	if code == "" {
+		league := b.leagues.Default()
+		return &league, nil
+	}
+	return b.leagues.Resolve(strings.ToUpper(code))
+}
+
+func (b *TelegramBot) leagueCodes() []string {
+	var codes []string
+	for _, league := range b.leagues.All() {
+		codes = append(codes, league.Code)
+	}
+	return codes
+}
+
+// table renders the current table of a league as a preformatted block.
+func (b *TelegramBot) table(ctx context.Context, code string) string {
+	league, err := b.league(code)
+	if err != nil {
+		return fmt.Sprintf("I don't know the league %q. Try /table with one of %s.", code, strings.Join(b.leagueCodes(), ", "))
+	}
+
+	season, err := b.seasons.CurrentSeason(ctx, league.Code)
+	if err != nil {
+		fmt.Println("telegram: could not resolve season:", err)
+		return "Sorry, I couldn't fetch the table. Please try again later."
+	}
+	standings, err := b.standings.GetStandings(ctx, league.APISportsID, season)
+	if err != nil || standings == nil || len(standings.Standings) == 0 {
+		if err != nil {
+			fmt.Println("telegram: could not fetch standings:", err)
+		}
+		return "Sorry, I couldn't fetch the table. Please try again later."
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%s %d*\n```\n", league.Name("en"), season)
+	fmt.Fprintf(&sb, "%2s %-18s %2s %3s %3s\n", "#", "Team", "P", "GD", "Pts")
+	for _, row := range standings.Standings {
+		name := []rune(row.TeamName)
+		if len(name) > 18 {
+			name = name[:18]
+		}
+		fmt.Fprintf(&sb, "%2d %-18s %2d %3d %3d\n", row.Rank, string(name), row.MatchesPlayed, row.GoalsDiff, row.Points)
+	}
+	sb.WriteString("```")
+	return sb.String()
+}
+
+// live lists the matches in play, in one league or in all of them.
+func (b *TelegramBot) live(ctx context.Context, code string) string {
+	leagues := b.leagues.All()
+	if code != "" {
+		league, err := b.league(code)
+		if err != nil {
+			return fmt.Sprintf("I don't know the league %q. Try /live with one of %s.", code, strings.Join(b.leagueCodes(), ", "))
+		}
+		leagues = []domain.LeagueConfig{*league}
+	}
+
+	var sb strings.Builder
+	for _, league := range leagues {
+		fixtures, err := b.fixtures.GetLiveMatches(ctx, league.Code)
+		if err != nil {
+			fmt.Println("telegram: could not fetch live matches:", err)
+			continue
+		}
+		if fixtures == nil || len(*fixtures) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "*%s*\n", league.Name("en"))
+		for _, f := range *fixtures {
+			fmt.Fprintf(&sb, "%s %s %s %s\n", f.HomeTeam.Name, liveScore(f.Goals), f.AwayTeam.Name, liveMinute(f.Status))
+		}
+		sb.WriteString("\n")
+	}
+	if sb.Len() == 0 {
+		return "No matches in play right now."
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func liveScore(g domain.Goals) string {
+	if g.Home == nil || g.Away == nil {
+		return "v"
+	}
+	return fmt.Sprintf("%d-%d", *g.Home, *g.Away)
+}
+
+func liveMinute(s domain.Status) string {
+	switch {
+	case s.Elapsed > 0 && s.Extra > 0:
+		return fmt.Sprintf("(%d+%d')", s.Elapsed, s.Extra)
+	case s.Elapsed > 0 && s.Short != "HT":
+		return fmt.Sprintf("(%d')", s.Elapsed)
+	}
+	return "(" + s.Short + ")"
+}
+
+// follow starts or stops alerts about a team for the chat. The team may be
+// named any way the alias list knows, in any configured league.
+func (b *TelegramBot) follow(ctx context.Context, chatID int64, query string, on bool) string {
+	verb := "/follow"
+	if !on {
+		verb = "/unfollow"
+	}
+	if query == "" {
+		return "Which team? For example: " + verb + " St. George"
+	}
+
+	matches := b.teams.Resolve(query, "", 1)
+	if len(matches) == 0 {
+		return fmt.Sprintf("I couldn't find a team called %q.", query)
+	}
+	team := matches[0]
+	if team.ID == 0 {
+		// ids are learnt as teams are seen upstream
+		return "I know " + team.Name + ", but not their fixtures yet. Please try again later."
+	}
+	userID := domain.TelegramUserID(chatID)
+
+	if !on {
+		err := b.follows.Unfollow(ctx, userID, team.ID)
+		switch {
+		case errors.Is(err, domain.ErrFollowNotFound):
+			return "You don't follow " + team.Name + "."
+		case err != nil:
+			fmt.Println("telegram: could not unfollow:", err)
+			return "Sorry, something went wrong. Please try again later."
+		}
+		return "You no longer follow " + team.Name + "."
+	}
+
+	if _, err := b.follows.Follow(ctx, userID, team.ID, true); err != nil {
+		if errors.Is(err, domain.ErrTeamNotFound) {
+			return fmt.Sprintf("I couldn't find a team called %q.", query)
+		}
+		fmt.Println("telegram: could not follow:", err)
+		return "Sorry, something went wrong. Please try again later."
+	}
+	return "You now follow " + team.Name + ". I'll message you here about their goals and results."
+}
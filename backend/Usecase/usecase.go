@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	// "log"
 
@@ -19,19 +21,37 @@ type TeamUsecases interface {
 	StatisticsByID(ctx context.Context, league, season, team int) (*domain.TeamComparison, error)
 	GetTeamByID(ctx context.Context, teamID int) (*domain.Team, error)
 	FetchAndCacheTeams(ctx context.Context, leagueID, season int) error
+	HeadToHead(ctx context.Context, teamA, teamB, last int) (*domain.HeadToHead, error)
 }
 
-func NewTeamUsecase(repo domain.IRedisRepo, api domain.IAPIService) TeamUsecases {
-	return &TeamUsecase{teamRepo: repo, api: api}
+func NewTeamUsecase(repo domain.IRedisRepo, cache *repository.SWRCache, resolver ITeamResolver, api domain.IAPIService, leagues domain.ILeagueRegistry) TeamUsecases {
+	return &TeamUsecase{teamRepo: repo, cache: cache, resolver: resolver, api: api, leagues: leagues}
 }
 
 type TeamUsecase struct {
 	teamRepo domain.IRedisRepo
+	cache    *repository.SWRCache
+	resolver ITeamResolver
 	api      domain.IAPIService
+	leagues  domain.ILeagueRegistry
 }
 
+var (
+	// Team statistics move with every matchday.
+	teamStatsPolicy = repository.CachePolicy{FreshFor: 6 * time.Hour, StaleFor: 7 * 24 * time.Hour}
+	// Team bios hardly ever change.
+	teamBioPolicy = repository.CachePolicy{FreshFor: 7 * 24 * time.Hour, StaleFor: 30 * 24 * time.Hour}
+	// Two teams meet a few times a season at most.
+	headToHeadPolicy = repository.CachePolicy{FreshFor: 12 * time.Hour, StaleFor: 30 * 24 * time.Hour}
+)
+
 func (tu *TeamUsecase) GetTeam(ctx context.Context, teamId string) (*domain.Team, error) {
-	return tu.teamRepo.Get(ctx, teamId)
+	if id, err := strconv.Atoi(teamId); err == nil {
+		return tu.GetTeamByID(ctx, id)
+	}
+	return repository.Fetch(ctx, tu.cache, "teambio:"+teamId, teamBioPolicy, func(ctx context.Context) (*domain.Team, error) {
+		return tu.teamRepo.Get(ctx, teamId)
+	})
 }
 
 func (tu *TeamUsecase) AddTeam(ctx context.Context, team *domain.Team) error {
@@ -40,62 +60,125 @@ func (tu *TeamUsecase) AddTeam(ctx context.Context, team *domain.Team) error {
 
 func (tu *TeamUsecase) Statistics(ctx context.Context, league, season int, team string) (*domain.TeamComparison, error) {
 
-	teamID, err := tu.teamRepo.GetID(ctx, team)
-	if err != nil {
-		fmt.Println("error in statistics while reading data from redis")
-		return nil, domain.ErrInternalServer
-	}
-
-	if stats, err := tu.teamRepo.GetTeamStats(ctx, teamID); err == nil && stats != nil {
-		return stats, nil
+	code := ""
+	if l, err := tu.leagues.ByAPISportsID(league); err == nil {
+		code = l.Code
 	}
-
-	stats, err := tu.api.Statistics(league, season, teamID)
+	teamID, err := tu.resolver.ResolveID(ctx, team, code)
 	if err != nil {
+		fmt.Printf("could not resolve team %q: %v\n", team, err)
 		return nil, err
 	}
-	
-	_ = tu.teamRepo.SaveTeamStats(ctx, teamID, stats)
 
-	return stats, nil
+	return tu.StatisticsByID(ctx, league, season, teamID)
 }
 
+func (tu *TeamUsecase) StatisticsByID(ctx context.Context, league, season, team int) (*domain.TeamComparison, error) {
+	key := fmt.Sprintf("teamstats:%d:%d:%d", league, season, team)
+	return repository.Fetch(ctx, tu.cache, key, teamStatsPolicy, func(ctx context.Context) (*domain.TeamComparison, error) {
+		return tu.api.Statistics(ctx, league, season, team)
+	})
+}
 
-func( tu *TeamUsecase) StatisticsByID(ctx context.Context, league, season, team int) (*domain.TeamComparison, error){
-
-	if stats, err := tu.teamRepo.GetTeamStats(ctx, team); err == nil && stats != nil {
-		fmt.Println("stats: ", stats)
-		return stats, nil
+// HeadToHead returns the record of the last meetings of two teams, counted
+// from teamA's side.
+func (tu *TeamUsecase) HeadToHead(ctx context.Context, teamA, teamB, last int) (*domain.HeadToHead, error) {
+	if teamA == teamB {
+		return nil, ErrInvalidInput
 	}
 
-
-	fmt.Println("api called")
-	stats, err := tu.api.Statistics(league, season, team)
+	// the same meetings serve both orders of the pair
+	lo, hi := min(teamA, teamB), max(teamA, teamB)
+	key := fmt.Sprintf("h2h:%d-%d:%d", lo, hi, last)
+	meetings, err := repository.Fetch(ctx, tu.cache, key, headToHeadPolicy, func(ctx context.Context) (*[]domain.PrevFixtures, error) {
+		return tu.api.HeadToHead(ctx, lo, hi, last)
+	})
 	if err != nil {
-		fmt.Println("err : ", err)
 		return nil, err
 	}
 
-	err = tu.teamRepo.SaveTeamStats(ctx, team, stats)
-	if err != nil {
-		fmt.Println("err :", err)
+	h2h := &domain.HeadToHead{
+		TeamA:    domain.MTeam{ID: teamA},
+		TeamB:    domain.MTeam{ID: teamB},
+		Meetings: []domain.PrevFixtures{},
+	}
+	if meetings != nil {
+		for _, m := range *meetings {
+			if !finishedMeeting(m) {
+				continue
+			}
+			home, away := *m.Goals.Home, *m.Goals.Away
+			goalsA, goalsB := home, away
+			if m.HomeTeam.ID == teamA {
+				h2h.TeamA, h2h.TeamB = m.HomeTeam, m.AwayTeam
+			} else {
+				h2h.TeamA, h2h.TeamB = m.AwayTeam, m.HomeTeam
+				goalsA, goalsB = away, home
+			}
+
+			h2h.Played++
+			h2h.GoalsA += goalsA
+			h2h.GoalsB += goalsB
+			switch {
+			case goalsA > goalsB:
+				h2h.WinsA++
+			case goalsA < goalsB:
+				h2h.WinsB++
+			default:
+				h2h.Draws++
+			}
+			h2h.Meetings = append(h2h.Meetings, m)
+		}
 	}
+	sort.Slice(h2h.Meetings, func(i, j int) bool { return h2h.Meetings[i].Date > h2h.Meetings[j].Date })
 
-	return stats, nil
+	// the teams never met, so name them from their profiles
+	if h2h.Played == 0 {
+		if team, err := tu.GetTeamByID(ctx, teamA); err == nil {
+			h2h.TeamA = domain.MTeam{ID: teamA, Name: team.Name, Logo: team.CrestURL}
+		}
+		if team, err := tu.GetTeamByID(ctx, teamB); err == nil {
+			h2h.TeamB = domain.MTeam{ID: teamB, Name: team.Name, Logo: team.CrestURL}
+		}
+	}
+	return h2h, nil
+}
 
+// finishedMeeting reports whether a fixture has been played to a result.
+// Penalty shoot-outs count as draws.
+func finishedMeeting(f domain.PrevFixtures) bool {
+	if f.Goals.Home == nil || f.Goals.Away == nil {
+		return false
+	}
+	switch f.Status.Short {
+	case "FT", "AET", "PEN":
+		return true
+	}
+	return false
 }
 
 func (tu *TeamUsecase) GetTeamByID(ctx context.Context, teamID int) (*domain.Team, error) {
+	key := fmt.Sprintf("teambio:%d", teamID)
+	return repository.Fetch(ctx, tu.cache, key, teamBioPolicy, func(ctx context.Context) (*domain.Team, error) {
+		return tu.lookupTeam(ctx, teamID)
+	})
+}
+
+// lookupTeam finds a team in the cached league rosters, sweeping the
+// configured leagues and seasons upstream when it is not there.
+func (tu *TeamUsecase) lookupTeam(ctx context.Context, teamID int) (*domain.Team, error) {
 	team, err := tu.teamRepo.GetTeamByID(ctx, teamID)
 	if err == nil {
 		return team, nil
 	}
-	leagues := []int{363, 39} // ETH and EPL
-	seasons := []int{2021, 2022, 2023}
-
-	for _, leagueID := range leagues {
-		for _, season := range seasons {
-			teamsResp, err := tu.api.GetTeams(leagueID, season)
+	for _, league := range tu.leagues.All() {
+		leagueID := league.APISportsID
+		for _, season := range league.Seasons {
+			teamsResp, err := tu.api.GetTeams(ctx, leagueID, season)
+			if errors.Is(err, domain.ErrQuotaExhausted) {
+				// Defer the rest of the sweep until the budget recovers
+				return nil, err
+			}
 			if err != nil {
 				continue
 			}
@@ -107,11 +190,12 @@ func (tu *TeamUsecase) GetTeamByID(ctx context.Context, teamID int) (*domain.Tea
 					ID:       strconv.Itoa(teamResp.Team.ID),
 					Name:     teamResp.Team.Name,
 					Short:    "",
-					League:   getLeagueName(leagueID),
+					League:   tu.leagueName(leagueID),
 					CrestURL: teamResp.Team.Logo,
 					Bio:      fmt.Sprintf("Founded: %d, Country: %s", getFoundedYear(teamResp.Team.Founded), teamResp.Team.Country),
 				}
 				teams = append(teams, team)
+				tu.registerTeam(ctx, teamResp.Team.ID, teamResp.Team.Name, leagueID)
 			}
 
 			// Cache all teams
@@ -130,7 +214,7 @@ func (tu *TeamUsecase) GetTeamByID(ctx context.Context, teamID int) (*domain.Tea
 }
 
 func (tu *TeamUsecase) FetchAndCacheTeams(ctx context.Context, leagueID, season int) error {
-	teamsResp, err := tu.api.GetTeams(leagueID, season)
+	teamsResp, err := tu.api.GetTeams(ctx, leagueID, season)
 	if err != nil {
 		return err
 	}
@@ -142,12 +226,12 @@ func (tu *TeamUsecase) FetchAndCacheTeams(ctx context.Context, leagueID, season
 			ID:       strconv.Itoa(teamResp.Team.ID),
 			Name:     teamResp.Team.Name,
 			Short:    "",
-			League:   getLeagueName(leagueID),
+			League:   tu.leagueName(leagueID),
 			CrestURL: teamResp.Team.Logo,
 			Bio:      fmt.Sprintf("Founded: %d, Country: %s", getFoundedYear(teamResp.Team.Founded), teamResp.Team.Country),
 		}
 		teams = append(teams, team)
-		tu.teamRepo.CacheTeamID(ctx, team.Name, team.ID)
+		tu.registerTeam(ctx, teamResp.Team.ID, teamResp.Team.Name, leagueID)
 	}
 
 	// Cache all teams
@@ -156,15 +240,22 @@ func (tu *TeamUsecase) FetchAndCacheTeams(ctx context.Context, leagueID, season
 
 
 // Helper functions
-func getLeagueName(leagueID int) string {
-	switch leagueID {
-	case 363:
-		return "Ethiopian Premier League"
-	case 39:
-		return "English Premier League"
-	default:
+func (tu *TeamUsecase) registerTeam(ctx context.Context, teamID int, name string, leagueID int) {
+	league, err := tu.leagues.ByAPISportsID(leagueID)
+	if err != nil {
+		return
+	}
+	if err := tu.resolver.Register(ctx, teamID, name, league.Code); err != nil {
+		fmt.Printf("could not register team %s: %v\n", name, err)
+	}
+}
+
+func (tu *TeamUsecase) leagueName(leagueID int) string {
+	league, err := tu.leagues.ByAPISportsID(leagueID)
+	if err != nil {
 		return "Unknown League"
 	}
+	return league.Name("en")
 }
 
 func getFoundedYear(founded *int) int {
@@ -179,14 +270,12 @@ type FixtureUsecase interface {
 }
 
 type fixtureUsecase struct {
-	repo  repository.FixtureRepo
-	cache repository.FixtureRepo
+	repo repository.FixtureRepo
 }
 
-func NewFixtureUsecase(r repository.FixtureRepo, c repository.FixtureRepo) FixtureUsecase {
+func NewFixtureUsecase(r repository.FixtureRepo) FixtureUsecase {
 	return &fixtureUsecase{
-		repo:  r,
-		cache: c,
+		repo: r,
 	}
 }
 
@@ -195,19 +284,10 @@ func (uc *fixtureUsecase) GetFixtures(ctx context.Context, league, team, season,
 		return nil, errors.New("league is required")
 	}
 
-	// Try cache first
-	fixtures, err := uc.cache.GetFixtures(league, team, season, from, to)
-	if err == nil && len(fixtures) > 0 {
-		fmt.Printf("Cache hit for fixtures (league=%s, team=%s, season=%s, from=%s, to=%s)\n", league, team, season, from, to)
-		return fixtures, nil
-	}
-
-	fmt.Printf("Cache miss for fixtures (league=%s, team=%s, season=%s, from=%s, to=%s), fetching from API\n", league, team, season, from, to)
-
-	// Fallback to API repo
-	fixtures, err = uc.repo.GetFixtures(league, team, season, from, to)
+	// The repo serves from the stale-while-revalidate cache and falls back to the API
+	fixtures, err := uc.repo.GetFixtures(ctx, league, team, season, from, to)
 	if err != nil {
-		fmt.Printf("API fetch failed (league=%s, team=%s, season=%s, from=%s, to=%s): %v\n", league, team, season, from, to, err)
+		fmt.Printf("fixtures fetch failed (league=%s, team=%s, season=%s, from=%s, to=%s): %v\n", league, team, season, from, to, err)
 		return nil, err
 	}
 
@@ -215,15 +295,6 @@ func (uc *fixtureUsecase) GetFixtures(ctx context.Context, league, team, season,
 		return []domain.Fixture{}, nil
 	}
 
-	// Cache the results for future requests
-	if apiRepo, ok := uc.cache.(*repository.APIRepo); ok && apiRepo.RDB != nil {
-		if err := apiRepo.SetFixturesCache(league, team, season, from, to, fixtures); err != nil {
-			fmt.Printf("Failed to cache fixtures: %v\n", err)
-		} else {
-			fmt.Printf("Successfully cached fixtures for (league=%s, team=%s, season=%s, from=%s, to=%s)\n", league, team, season, from, to)
-		}
-	}
-
 	return fixtures, nil
 }
 